@@ -133,21 +133,30 @@ func (c *EventServiceClient) GetEventsByAggregate(ctx context.Context, req *pb.G
 	return response.Events, nil
 }
 
-// SubscribeToEvents subscribes to events
-func (c *EventServiceClient) SubscribeToEvents(ctx context.Context, req *pb.SubscribeToEventsRequest) (*pb.SubscribeToEventsResponse, error) {
-	grpcReq := &pb.SubscribeToEventsRequest{
-		ConsumerGroup: req.ConsumerGroup,
-		EventTypes:    req.EventTypes,
-		CallbackUrl:   req.CallbackUrl,
+// SubscribeToEvents opens a server-streamed subscription to events of
+// req.EventTypes for req.ConsumerGroup, resuming after
+// req.LastAckedEventId. Most callers shouldn't call this directly - use
+// EventConsumer, which owns the reconnect/backoff/ACK bookkeeping around
+// the raw stream.
+func (c *EventServiceClient) SubscribeToEvents(ctx context.Context, req *pb.SubscribeToEventsRequest) (pb.EventService_SubscribeToEventsClient, error) {
+	stream, err := c.client.SubscribeToEvents(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to events: %w", err)
 	}
+	return stream, nil
+}
 
-	response, err := c.client.SubscribeToEvents(ctx, grpcReq)
+// AckEvent acknowledges successful handling of req.EventId by
+// req.ConsumerGroup, letting the event-service advance that group's
+// delivery cursor so the event isn't redelivered.
+func (c *EventServiceClient) AckEvent(ctx context.Context, req *pb.AckEventRequest) (*pb.AckEventResponse, error) {
+	response, err := c.client.AckEvent(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to subscribe to events: %w", err)
+		return nil, fmt.Errorf("failed to ack event %d for consumer group %s: %w", req.EventId, req.ConsumerGroup, err)
 	}
 
 	if !response.Success {
-		return nil, fmt.Errorf("subscription failed: %s", response.Message)
+		return nil, fmt.Errorf("ack of event %d failed: %s", req.EventId, response.Message)
 	}
 
 	return response, nil
@@ -0,0 +1,302 @@
+// post-service/clients/event_client/event_consumer.go
+package clients
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"math/rand"
+	"sync"
+	"time"
+
+	pb "post-service/protos/eventpb"
+)
+
+// CursorStore persists the last event ID a consumer group has
+// successfully ACKed, so a restarted EventConsumer resumes the stream
+// instead of replaying the whole event log or silently skipping events it
+// never saw.
+type CursorStore interface {
+	// LoadCursor returns the last-acked event ID for group, or 0 if the
+	// group has never ACKed anything.
+	LoadCursor(group string) (uint64, error)
+	// SaveCursor persists the last-acked event ID for group.
+	SaveCursor(group string, eventID uint64) error
+}
+
+// Handler processes a single event of the type it was registered for.
+// Returning an error leaves the event un-ACKed, so it is redelivered the
+// next time the stream reconnects.
+type Handler func(ctx context.Context, evt *pb.Event) error
+
+const (
+	consumerBufferSize    = 256
+	consumerMinBackoff    = 500 * time.Millisecond
+	consumerMaxBackoff    = time.Minute
+	consumerAckTimeout    = 5 * time.Second
+	consumerHandleTimeout = 30 * time.Second
+)
+
+// EventConsumer subscribes to the event-service's SubscribeToEvents stream
+// for a consumer group, dispatches each event to the Handler registered
+// for its type, and ACKs once the handler succeeds. A bounded in-memory
+// buffer sits between receiving and dispatching, so a slow handler applies
+// backpressure on Recv instead of events piling up unboundedly; stream
+// errors trigger a reconnect with exponential backoff + jitter, resuming
+// from the consumer group's last ACK.
+type EventConsumer struct {
+	client        *EventServiceClient
+	cursors       CursorStore
+	consumerGroup string
+	eventTypes    []string
+
+	handlers map[string]Handler
+	buffer   chan *pb.Event
+
+	// mu guards highWaterMark/pendingAcked, written from ack (dispatchLoop's
+	// goroutine) and reset from streamLoop's goroutine on every (re)subscribe.
+	mu            sync.Mutex
+	highWaterMark uint64
+	pendingAcked  map[uint64]struct{}
+
+	stopCh chan struct{}
+	doneCh chan struct{}
+	once   sync.Once
+}
+
+// NewEventConsumer builds a consumer for consumerGroup, subscribed to
+// eventTypes. Call On to register handlers and Start to begin consuming.
+func NewEventConsumer(client *EventServiceClient, cursors CursorStore, consumerGroup string, eventTypes []string) *EventConsumer {
+	return &EventConsumer{
+		client:        client,
+		cursors:       cursors,
+		consumerGroup: consumerGroup,
+		eventTypes:    eventTypes,
+		handlers:      make(map[string]Handler),
+		buffer:        make(chan *pb.Event, consumerBufferSize),
+		stopCh:        make(chan struct{}),
+		doneCh:        make(chan struct{}),
+	}
+}
+
+// On registers the handler invoked for events of the given type.
+// Registering a handler for the same type twice replaces the previous one.
+func (c *EventConsumer) On(eventType string, handler Handler) {
+	c.handlers[eventType] = handler
+}
+
+// Start opens the stream and begins dispatching events in background
+// goroutines.
+func (c *EventConsumer) Start() {
+	go c.dispatchLoop()
+	go c.streamLoop()
+}
+
+// Stop signals the stream and dispatch loops to exit and waits for the
+// events already in the buffer to finish handling, so callers can
+// coordinate it with the rest of graceful shutdown.
+func (c *EventConsumer) Stop() {
+	c.once.Do(func() { close(c.stopCh) })
+	<-c.doneCh
+}
+
+// streamLoop opens (and, on error, re-opens) the SubscribeToEvents stream,
+// pushing every received event onto the bounded buffer.
+func (c *EventConsumer) streamLoop() {
+	// Cancel the stream's context on Stop, so a Recv blocked on an idle
+	// stream returns instead of holding up shutdown.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-c.stopCh
+		cancel()
+	}()
+
+	attempt := 0
+	for {
+		select {
+		case <-c.stopCh:
+			close(c.buffer)
+			return
+		default:
+		}
+
+		lastAcked, err := c.cursors.LoadCursor(c.consumerGroup)
+		if err != nil {
+			log.Printf("❌ EventConsumer(%s): failed to load cursor, resubscribing from the start: %v", c.consumerGroup, err)
+			lastAcked = 0
+		}
+
+		// Reset the in-memory high-water mark to what's actually
+		// persisted: any event acked out of order during the previous
+		// stream but never folded into a contiguous run will be
+		// redelivered from lastAcked onward anyway.
+		c.mu.Lock()
+		c.highWaterMark = lastAcked
+		c.pendingAcked = make(map[uint64]struct{})
+		c.mu.Unlock()
+
+		stream, err := c.client.SubscribeToEvents(ctx, &pb.SubscribeToEventsRequest{
+			ConsumerGroup:    c.consumerGroup,
+			EventTypes:       c.eventTypes,
+			LastAckedEventId: lastAcked,
+		})
+		if err != nil {
+			log.Printf("❌ EventConsumer(%s): failed to open event stream: %v", c.consumerGroup, err)
+			if !c.wait(c.backoff(attempt)) {
+				close(c.buffer)
+				return
+			}
+			attempt++
+			continue
+		}
+		attempt = 0
+
+		if !c.drain(stream) {
+			close(c.buffer)
+			return
+		}
+		if !c.wait(c.backoff(attempt)) {
+			close(c.buffer)
+			return
+		}
+		attempt++
+	}
+}
+
+// drain reads one stream until it ends or errors, pushing events onto the
+// bounded buffer. It returns false if the consumer was stopped while
+// draining, true if the stream simply needs to be re-opened.
+func (c *EventConsumer) drain(stream pb.EventService_SubscribeToEventsClient) bool {
+	for {
+		evt, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			log.Printf("⚠️ EventConsumer(%s): event stream closed by server, reconnecting", c.consumerGroup)
+			return true
+		}
+		if err != nil {
+			log.Printf("❌ EventConsumer(%s): event stream error, reconnecting: %v", c.consumerGroup, err)
+			return true
+		}
+
+		select {
+		case c.buffer <- evt:
+		case <-c.stopCh:
+			return false
+		}
+	}
+}
+
+// backoff returns the exponentially increasing, jittered delay for the
+// given attempt count, capped at consumerMaxBackoff so a flapping
+// event-service doesn't turn reconnects into a tight retry loop.
+func (c *EventConsumer) backoff(attempt int) time.Duration {
+	if attempt > 10 {
+		attempt = 10
+	}
+	delay := consumerMinBackoff << attempt
+	if delay <= 0 || delay > consumerMaxBackoff {
+		delay = consumerMaxBackoff
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay)/2+1))
+}
+
+// wait sleeps for d, returning false early if the consumer was stopped.
+func (c *EventConsumer) wait(d time.Duration) bool {
+	select {
+	case <-time.After(d):
+		return true
+	case <-c.stopCh:
+		return false
+	}
+}
+
+// dispatchLoop pulls events off the bounded buffer and hands each to
+// dispatchOne in turn, so a slow handler naturally blocks further Recv
+// calls upstream rather than needing its own backpressure signal.
+func (c *EventConsumer) dispatchLoop() {
+	defer close(c.doneCh)
+
+	for evt := range c.buffer {
+		c.dispatchOne(evt)
+	}
+}
+
+// dispatchOne runs the handler registered for evt's type and ACKs on
+// success. Events with no registered handler are ACKed immediately - a
+// consumer group only cares about the types it asked to receive.
+func (c *EventConsumer) dispatchOne(evt *pb.Event) {
+	handler, ok := c.handlers[evt.EventType]
+	if !ok {
+		c.ack(evt.Id)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), consumerHandleTimeout)
+	defer cancel()
+
+	if err := handler(ctx, evt); err != nil {
+		log.Printf("❌ EventConsumer(%s): handler for %s failed on event %d, leaving it un-acked: %v", c.consumerGroup, evt.EventType, evt.Id, err)
+		return
+	}
+
+	c.ack(evt.Id)
+}
+
+func (c *EventConsumer) ack(eventID uint64) {
+	ctx, cancel := context.WithTimeout(context.Background(), consumerAckTimeout)
+	defer cancel()
+
+	if _, err := c.client.AckEvent(ctx, &pb.AckEventRequest{
+		ConsumerGroup: c.consumerGroup,
+		EventId:       eventID,
+	}); err != nil {
+		log.Printf("❌ EventConsumer(%s): failed to ack event %d: %v", c.consumerGroup, eventID, err)
+		return
+	}
+
+	// Only persist a cursor that covers every event up to and including
+	// it - if an earlier event is still outstanding (failed, so never
+	// acked), the persisted cursor must stay behind it, or a reconnect
+	// would resume past the failed event and lose it for good.
+	highWaterMark := c.advanceHighWaterMark(eventID)
+	if highWaterMark == 0 {
+		return
+	}
+
+	if err := c.cursors.SaveCursor(c.consumerGroup, highWaterMark); err != nil {
+		log.Printf("❌ EventConsumer(%s): failed to persist cursor at event %d: %v", c.consumerGroup, highWaterMark, err)
+	}
+}
+
+// advanceHighWaterMark records eventID as acked and folds it (and any
+// other already-acked events immediately following the current mark) into
+// the contiguous high-water mark. It returns the new mark, or 0 if eventID
+// only filled in ahead of a gap - i.e. an earlier event is still
+// outstanding - so the caller knows not to persist anything yet.
+func (c *EventConsumer) advanceHighWaterMark(eventID uint64) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if eventID <= c.highWaterMark {
+		return 0
+	}
+	c.pendingAcked[eventID] = struct{}{}
+
+	moved := false
+	for {
+		next := c.highWaterMark + 1
+		if _, ok := c.pendingAcked[next]; !ok {
+			break
+		}
+		delete(c.pendingAcked, next)
+		c.highWaterMark = next
+		moved = true
+	}
+
+	if !moved {
+		return 0
+	}
+	return c.highWaterMark
+}
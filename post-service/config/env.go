@@ -11,8 +11,32 @@ type Config struct {
 	DBPassword          string
 	DBName              string
 	GRPCPort            string
+	HTTPPort            string
 	UserServiceAddress  string
 	EventServiceAddress string
+
+	// SearchBackend selects the search.Indexer implementation: "postgres"
+	// (default, uses the search_vector column), "meilisearch", or "sqlite"
+	// (a plain LIKE query, for local dev environments without Postgres'
+	// tsvector/GIN/ts_rank_cd support).
+	SearchBackend string
+	SearchAddress string
+	SearchAPIKey  string
+	SearchIndex   string
+
+	// StorageBackend selects the storage.Backend implementation: "local"
+	// (default) or "s3".
+	StorageBackend   string
+	StorageBaseDir   string
+	StoragePublicURL string
+	StorageSignKey   string
+	S3Bucket         string
+	S3Region         string
+
+	// FederationBaseURL is this instance's externally-reachable base URL,
+	// used to build ActivityPub actor/object IDs (e.g.
+	// https://blog.example.com).
+	FederationBaseURL string
 }
 
 func LoadConfig() *Config {
@@ -23,8 +47,23 @@ func LoadConfig() *Config {
 		DBPassword:          getEnv("DB_PASSWORD", "47@n2EEr"),
 		DBName:              getEnv("DB_NAME", "post_db"),
 		GRPCPort:            getEnv("GRPC_PORT", "50052"),
+		HTTPPort:            getEnv("HTTP_PORT", "8080"),
 		UserServiceAddress:  getEnv("USER_SERVICE_ADDRESS", "localhost:50052"),
 		EventServiceAddress: getEnv("EVENT_SERVICE_ADDRESS", "localhost:50055"),
+
+		SearchBackend: getEnv("SEARCH_BACKEND", "postgres"),
+		SearchAddress: getEnv("SEARCH_ADDRESS", "http://localhost:7700"),
+		SearchAPIKey:  getEnv("SEARCH_API_KEY", ""),
+		SearchIndex:   getEnv("SEARCH_INDEX", "posts"),
+
+		StorageBackend:   getEnv("STORAGE_BACKEND", "local"),
+		StorageBaseDir:   getEnv("STORAGE_BASE_DIR", "./uploads"),
+		StoragePublicURL: getEnv("STORAGE_PUBLIC_URL", "http://localhost:8080"),
+		StorageSignKey:   getEnv("STORAGE_SIGN_KEY", "dev-upload-signing-key"),
+		S3Bucket:         getEnv("S3_BUCKET", "word-weave-attachments"),
+		S3Region:         getEnv("S3_REGION", "us-east-1"),
+
+		FederationBaseURL: getEnv("FEDERATION_BASE_URL", "http://localhost:8080"),
 	}
 }
 
@@ -0,0 +1,32 @@
+package database
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// EnsureSearchIndex adds the generated `search_vector` tsvector column and
+// its GIN index to the posts table if they don't exist yet. GORM's
+// AutoMigrate doesn't understand generated columns, so this runs as a
+// separate, idempotent step alongside it.
+func EnsureSearchIndex(db *gorm.DB) error {
+	statements := []string{
+		`ALTER TABLE posts ADD COLUMN IF NOT EXISTS search_vector tsvector
+			GENERATED ALWAYS AS (
+				setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+				setweight(to_tsvector('english', coalesce("desc", '')), 'B') ||
+				setweight(to_tsvector('english', coalesce(content, '')), 'C') ||
+				setweight(to_tsvector('english', coalesce(category, '')), 'D')
+			) STORED`,
+		`CREATE INDEX IF NOT EXISTS idx_posts_search_vector ON posts USING GIN (search_vector)`,
+	}
+
+	for _, stmt := range statements {
+		if err := db.Exec(stmt).Error; err != nil {
+			return fmt.Errorf("failed to apply search index migration: %w", err)
+		}
+	}
+
+	return nil
+}
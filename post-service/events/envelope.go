@@ -0,0 +1,100 @@
+// post-service/events/envelope.go
+package events
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Producer identifies this service as the source of events it publishes.
+const Producer = "post-service"
+
+// Envelope wraps every domain event published by post-service with the
+// metadata a consumer needs to process it safely: a stable identity
+// (EventID), a version so payload changes don't break older consumers
+// (EventType/EventVersion), and distributed-tracing correlation (TraceID,
+// CausationID, CorrelationID). Payload is kept as raw JSON so the envelope
+// itself never needs to change shape when a new event type is added.
+type Envelope struct {
+	EventID       string          `json:"event_id"`
+	EventType     string          `json:"event_type"`
+	EventVersion  string          `json:"event_version"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	TraceID       string          `json:"trace_id"`
+	CausationID   string          `json:"causation_id"`
+	CorrelationID string          `json:"correlation_id"`
+	Producer      string          `json:"producer"`
+	Payload       json.RawMessage `json:"payload"`
+}
+
+// newEnvelope marshals payload and wraps it in an Envelope. It's unexported
+// because callers should go through the typed New<Event> constructors in
+// post_events.go, which pin EventType/EventVersion to the payload shape
+// they build.
+func newEnvelope(eventType, eventVersion string, ids CorrelationIDs, payload interface{}) (*Envelope, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("marshal %s payload: %w", eventType, err)
+	}
+
+	return &Envelope{
+		EventID:       newEventID(),
+		EventType:     eventType,
+		EventVersion:  eventVersion,
+		OccurredAt:    time.Now().UTC(),
+		TraceID:       ids.TraceID,
+		CausationID:   ids.CausationID,
+		CorrelationID: ids.CorrelationID,
+		Producer:      Producer,
+		Payload:       raw,
+	}, nil
+}
+
+// Marshal renders the full envelope (metadata + payload) as JSON, suitable
+// for OutboxEvent.EventData.
+func (e *Envelope) Marshal() (string, error) {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return "", fmt.Errorf("marshal envelope: %w", err)
+	}
+	return string(b), nil
+}
+
+// MarshalMetadata renders just the routing/correlation fields as JSON,
+// suitable for OutboxEvent.Metadata — lets the dispatcher and any operator
+// tooling filter or trace events without parsing the full payload.
+func (e *Envelope) MarshalMetadata() (string, error) {
+	meta := struct {
+		TraceID       string    `json:"trace_id"`
+		CausationID   string    `json:"causation_id"`
+		CorrelationID string    `json:"correlation_id"`
+		Producer      string    `json:"producer"`
+		OccurredAt    time.Time `json:"occurred_at"`
+	}{
+		TraceID:       e.TraceID,
+		CausationID:   e.CausationID,
+		CorrelationID: e.CorrelationID,
+		Producer:      e.Producer,
+		OccurredAt:    e.OccurredAt,
+	}
+	b, err := json.Marshal(meta)
+	if err != nil {
+		return "", fmt.Errorf("marshal envelope metadata: %w", err)
+	}
+	return string(b), nil
+}
+
+// newEventID generates a random UUIDv4-formatted identifier without pulling
+// in an external dependency just for this.
+func newEventID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return fmt.Sprintf("unseeded-%d", time.Now().UnixNano())
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // variant 10
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
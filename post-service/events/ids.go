@@ -0,0 +1,61 @@
+// post-service/events/ids.go
+package events
+
+import (
+	"context"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// Metadata keys the gRPC gateway/upstream callers may set to thread
+// distributed-tracing correlation through to the events we publish.
+const (
+	traceIDHeader       = "x-trace-id"
+	causationIDHeader   = "x-causation-id"
+	correlationIDHeader = "x-correlation-id"
+)
+
+// CorrelationIDs carries the trace/causation/correlation IDs that get
+// stamped onto every Envelope so a consumer (or an operator reading logs)
+// can follow one logical operation across service boundaries.
+type CorrelationIDs struct {
+	TraceID       string
+	CausationID   string
+	CorrelationID string
+}
+
+// IDsFromContext reads correlation IDs propagated via incoming gRPC
+// metadata. A missing trace ID is freshly generated, and a missing
+// causation or correlation ID defaults to the trace ID, so a request with
+// no upstream trace still produces a fully-populated, self-consistent
+// envelope instead of empty fields.
+func IDsFromContext(ctx context.Context) CorrelationIDs {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	ids := CorrelationIDs{
+		TraceID:       firstOrEmpty(md, traceIDHeader),
+		CausationID:   firstOrEmpty(md, causationIDHeader),
+		CorrelationID: firstOrEmpty(md, correlationIDHeader),
+	}
+
+	if ids.TraceID == "" {
+		ids.TraceID = newEventID()
+	}
+	if ids.CausationID == "" {
+		ids.CausationID = ids.TraceID
+	}
+	// Absent an explicit correlation ID, the trace ID is the best anchor
+	// for grouping everything this request causes.
+	if ids.CorrelationID == "" {
+		ids.CorrelationID = ids.TraceID
+	}
+
+	return ids
+}
+
+func firstOrEmpty(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
@@ -0,0 +1,83 @@
+// post-service/events/post_events.go
+package events
+
+import "post-service/models"
+
+// FieldChange records a single field's value before and after a patch, so
+// consumers can react to specific changes (e.g. re-render a cached summary
+// only when title/desc changed) instead of re-fetching the whole post.
+type FieldChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// PostCreatedV1 is the payload of a "post.created" event.
+type PostCreatedV1 struct {
+	ID     uint   `json:"id"`
+	Title  string `json:"title"`
+	Slug   string `json:"slug"`
+	UserID string `json:"userId"`
+}
+
+// NewPostCreated builds the envelope for a newly created post.
+func NewPostCreated(post *models.Post, ids CorrelationIDs) (*Envelope, error) {
+	return newEnvelope("post.created", "v1", ids, PostCreatedV1{
+		ID:     post.ID,
+		Title:  post.Title,
+		Slug:   post.Slug,
+		UserID: post.UserID,
+	})
+}
+
+// PostUpdatedV1 is the payload of a "post.updated" event, published for a
+// full (PUT-style) update.
+type PostUpdatedV1 struct {
+	ID     uint   `json:"id"`
+	Title  string `json:"title"`
+	Slug   string `json:"slug"`
+	UserID string `json:"userId"`
+}
+
+// NewPostUpdated builds the envelope for a fully-replaced post.
+func NewPostUpdated(post *models.Post, ids CorrelationIDs) (*Envelope, error) {
+	return newEnvelope("post.updated", "v1", ids, PostUpdatedV1{
+		ID:     post.ID,
+		Title:  post.Title,
+		Slug:   post.Slug,
+		UserID: post.UserID,
+	})
+}
+
+// PostPatchedV1 is the payload of a "post.patched" event, published for a
+// partial (PATCH-style) update. Changes is keyed by field name so a
+// consumer can inspect exactly what moved without diffing two full posts.
+type PostPatchedV1 struct {
+	ID            uint                   `json:"id"`
+	UserID        string                 `json:"userId"`
+	UpdatedFields []string               `json:"updatedFields"`
+	Changes       map[string]FieldChange `json:"changes"`
+}
+
+// NewPostPatched builds the envelope for a partial post update.
+func NewPostPatched(post *models.Post, updatedFields []string, changes map[string]FieldChange, ids CorrelationIDs) (*Envelope, error) {
+	return newEnvelope("post.patched", "v1", ids, PostPatchedV1{
+		ID:            post.ID,
+		UserID:        post.UserID,
+		UpdatedFields: updatedFields,
+		Changes:       changes,
+	})
+}
+
+// PostDeletedV1 is the payload of a "post.deleted" event.
+type PostDeletedV1 struct {
+	ID     uint   `json:"id"`
+	UserID string `json:"userId"`
+}
+
+// NewPostDeleted builds the envelope for a deleted post.
+func NewPostDeleted(id uint, userID string, ids CorrelationIDs) (*Envelope, error) {
+	return newEnvelope("post.deleted", "v1", ids, PostDeletedV1{
+		ID:     id,
+		UserID: userID,
+	})
+}
@@ -0,0 +1,50 @@
+// post-service/federation/actor.go
+package federation
+
+import "fmt"
+
+// Actor is the minimal ActivityPub actor document published for each local
+// user, modeled as a single-author blog ("Person") the way WriteFreely
+// federates a blog's posts under its author's identity.
+type Actor struct {
+	Context           interface{}    `json:"@context"`
+	ID                string         `json:"id"`
+	Type              string         `json:"type"`
+	PreferredUsername string         `json:"preferredUsername"`
+	Inbox             string         `json:"inbox"`
+	Outbox            string         `json:"outbox"`
+	Followers         string         `json:"followers"`
+	PublicKey         ActorPublicKey `json:"publicKey"`
+}
+
+// ActorPublicKey embeds the actor's RSA public key so remote servers can
+// verify the HTTP signatures on activities this actor sends.
+type ActorPublicKey struct {
+	ID           string `json:"id"`
+	Owner        string `json:"owner"`
+	PublicKeyPem string `json:"publicKeyPem"`
+}
+
+// ActorURL returns the canonical actor URL for userID under baseURL.
+func ActorURL(baseURL, userID string) string {
+	return fmt.Sprintf("%s/actors/%s", baseURL, userID)
+}
+
+// NewActor builds the actor document for userID.
+func NewActor(baseURL, userID, publicKeyPEM string) *Actor {
+	actorURL := ActorURL(baseURL, userID)
+	return &Actor{
+		Context:           []string{ActivityStreamsContext, "https://w3id.org/security/v1"},
+		ID:                actorURL,
+		Type:              "Person",
+		PreferredUsername: userID,
+		Inbox:             actorURL + "/inbox",
+		Outbox:            actorURL + "/outbox",
+		Followers:         actorURL + "/followers",
+		PublicKey: ActorPublicKey{
+			ID:           actorURL + "#main-key",
+			Owner:        actorURL,
+			PublicKeyPem: publicKeyPEM,
+		},
+	}
+}
@@ -0,0 +1,14 @@
+// post-service/federation/host.go
+package federation
+
+import "net/url"
+
+// HostFromBaseURL extracts the host[:port] portion of baseURL for use in
+// "acct:user@host" WebFinger subjects.
+func HostFromBaseURL(baseURL string) string {
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Host == "" {
+		return baseURL
+	}
+	return u.Host
+}
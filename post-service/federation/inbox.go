@@ -0,0 +1,96 @@
+// post-service/federation/inbox.go
+package federation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+)
+
+// Activity is the generic envelope for an inbound ActivityPub activity.
+// Object is left as raw JSON since its shape depends on Type (a Follow's
+// object is an actor URL string, an Undo's object is a nested Activity).
+type Activity struct {
+	Context interface{}     `json:"@context"`
+	ID      string          `json:"id"`
+	Type    string          `json:"type"`
+	Actor   string          `json:"actor"`
+	Object  json.RawMessage `json:"object"`
+}
+
+// HandleInboxActivity processes one activity addressed to userID's inbox.
+// Follow adds the sender as a follower (and replies with Accept, per the
+// ActivityPub handshake); Undo of a Follow removes it; Like and Create are
+// acknowledged but otherwise ignored — this service doesn't maintain a
+// timeline of remote activity yet, so there's nothing further to do with
+// them besides not bouncing the sender with an error.
+func (s *Service) HandleInboxActivity(ctx context.Context, userID string, activity Activity) error {
+	switch activity.Type {
+	case "Follow":
+		return s.handleFollow(ctx, userID, activity)
+	case "Undo":
+		return s.handleUndo(userID, activity)
+	case "Like", "Create":
+		return nil
+	default:
+		return fmt.Errorf("unsupported activity type %q", activity.Type)
+	}
+}
+
+func (s *Service) handleFollow(ctx context.Context, userID string, follow Activity) error {
+	inbox, err := s.resolveInbox(follow.Actor)
+	if err != nil {
+		return fmt.Errorf("resolve follower inbox: %w", err)
+	}
+
+	if err := s.followers.AddFollower(userID, inbox, follow.Actor); err != nil {
+		return fmt.Errorf("store follower: %w", err)
+	}
+
+	if err := s.sendAccept(ctx, userID, follow, inbox); err != nil {
+		// The follower is already persisted; a failed Accept just means the
+		// remote server may not show them as following yet, so log and move
+		// on rather than undoing the follow.
+		log.Printf("⚠️ Federation: failed to send Accept to %s: %v", follow.Actor, err)
+	}
+	return nil
+}
+
+func (s *Service) handleUndo(userID string, undo Activity) error {
+	var inner Activity
+	if err := json.Unmarshal(undo.Object, &inner); err != nil {
+		return fmt.Errorf("parse Undo object: %w", err)
+	}
+	if inner.Type != "Follow" {
+		return nil
+	}
+	return s.followers.RemoveFollower(userID, undo.Actor)
+}
+
+func (s *Service) sendAccept(ctx context.Context, userID string, follow Activity, inbox string) error {
+	accept := map[string]interface{}{
+		"@context": ActivityStreamsContext,
+		"id":       follow.ID + "/accept",
+		"type":     "Accept",
+		"actor":    ActorURL(s.baseURL, userID),
+		"object":   follow,
+	}
+
+	body, err := json.Marshal(accept)
+	if err != nil {
+		return fmt.Errorf("marshal Accept: %w", err)
+	}
+
+	privatePEM, _, err := s.keys.GetOrCreateKeyPair(userID)
+	if err != nil {
+		return fmt.Errorf("load actor key: %w", err)
+	}
+	privateKey, err := ParsePrivateKey(privatePEM)
+	if err != nil {
+		return fmt.Errorf("parse actor key: %w", err)
+	}
+
+	keyID := ActorURL(s.baseURL, userID) + "#main-key"
+	return s.deliver(ctx, inbox, keyID, privateKey, body)
+}
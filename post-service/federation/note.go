@@ -0,0 +1,97 @@
+// post-service/federation/note.go
+package federation
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+)
+
+// ActivityStreamsContext is the JSON-LD context every ActivityPub document
+// this service produces is anchored to.
+const ActivityStreamsContext = "https://www.w3.org/ns/activitystreams"
+
+// Note is the ActivityStreams representation of a published post.
+type Note struct {
+	Context      interface{} `json:"@context"`
+	ID           string      `json:"id"`
+	Type         string      `json:"type"`
+	AttributedTo string      `json:"attributedTo"`
+	Content      string      `json:"content"`
+	URL          string      `json:"url"`
+	Published    time.Time   `json:"published"`
+	To           []string    `json:"to"`
+}
+
+// NoteInput is the subset of a models.Post needed to build its Note and
+// deliver it, kept separate from models.Post so this package doesn't
+// depend on gorm/database types.
+type NoteInput struct {
+	Slug      string
+	Title     string
+	Content   string
+	UserID    string
+	CreatedAt time.Time
+}
+
+// NewNote builds the Note document for a post, addressed to the public
+// collection and the author's followers — the same addressing WriteFreely
+// uses for federated blog posts.
+func NewNote(baseURL string, in NoteInput) *Note {
+	actorURL := ActorURL(baseURL, in.UserID)
+	noteURL := fmt.Sprintf("%s/posts/%s", baseURL, in.Slug)
+
+	return &Note{
+		Context:      ActivityStreamsContext,
+		ID:           noteURL,
+		Type:         "Note",
+		AttributedTo: actorURL,
+		Content:      renderHTML(in.Title, in.Content),
+		URL:          noteURL,
+		Published:    in.CreatedAt.UTC(),
+		To:           []string{"https://www.w3.org/ns/activitystreams#Public", actorURL + "/followers"},
+	}
+}
+
+// renderHTML produces a minimal HTML rendering of a post for the Note's
+// "content" field. Title and body are escaped; only the wrapper tags are
+// trusted markup.
+func renderHTML(title, content string) string {
+	var b strings.Builder
+	if title != "" {
+		b.WriteString("<h1>")
+		b.WriteString(html.EscapeString(title))
+		b.WriteString("</h1>")
+	}
+	b.WriteString("<p>")
+	b.WriteString(html.EscapeString(content))
+	b.WriteString("</p>")
+	return b.String()
+}
+
+// CreateActivity wraps a Note in the "Create" activity that actually gets
+// delivered to follower inboxes.
+type CreateActivity struct {
+	Context   interface{} `json:"@context"`
+	ID        string      `json:"id"`
+	Type      string      `json:"type"`
+	Actor     string      `json:"actor"`
+	Object    *Note       `json:"object"`
+	To        []string    `json:"to"`
+	Published time.Time   `json:"published"`
+}
+
+// NewCreateActivity wraps note in a Create activity attributed to its
+// author.
+func NewCreateActivity(note *Note) *CreateActivity {
+	return &CreateActivity{
+		Context:   ActivityStreamsContext,
+		ID:        note.ID + "/activity",
+		Type:      "Create",
+		Actor:     note.AttributedTo,
+		Object:    note,
+		To:        note.To,
+		Published: note.Published,
+	}
+}
@@ -0,0 +1,205 @@
+// post-service/federation/service.go
+package federation
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	event_client "post-service/clients/event_client"
+	eventpb "post-service/protos/eventpb"
+)
+
+const inboxRequestTimeout = 10 * time.Second
+
+// KeyStore persists per-actor RSA keypairs, generating one on first use.
+// Implemented by repository.FederationRepository; kept as an interface
+// here so this package never imports gorm/database types.
+type KeyStore interface {
+	GetOrCreateKeyPair(userID string) (privatePEM, publicPEM string, err error)
+}
+
+// FollowerStore persists who follows which local actor.
+type FollowerStore interface {
+	AddFollower(userID, followerInbox, followerActor string) error
+	RemoveFollower(userID, followerActor string) error
+	ListFollowerInboxes(userID string) ([]string, error)
+}
+
+// Service builds ActivityPub documents for local actors/posts and
+// delivers signed activities to follower inboxes, the way WriteFreely
+// federates blog posts under each author's actor identity.
+type Service struct {
+	baseURL     string
+	keys        KeyStore
+	followers   FollowerStore
+	eventClient *event_client.EventServiceClient
+	httpClient  *http.Client
+}
+
+func NewService(baseURL string, keys KeyStore, followers FollowerStore, eventClient *event_client.EventServiceClient) *Service {
+	return &Service{
+		baseURL:     baseURL,
+		keys:        keys,
+		followers:   followers,
+		eventClient: eventClient,
+		httpClient:  &http.Client{Timeout: inboxRequestTimeout},
+	}
+}
+
+// BaseURL returns this instance's externally-reachable base URL, used to
+// build actor/object IDs.
+func (s *Service) BaseURL() string {
+	return s.baseURL
+}
+
+// Actor returns the ActivityPub actor document for userID, generating its
+// keypair on first request.
+func (s *Service) Actor(userID string) (*Actor, error) {
+	_, publicPEM, err := s.keys.GetOrCreateKeyPair(userID)
+	if err != nil {
+		return nil, err
+	}
+	return NewActor(s.baseURL, userID, publicPEM), nil
+}
+
+// PublishNote signs a Create(Note) activity for a post and delivers it to
+// every follower of the post's author, then emits a post.federated event
+// for observability. A delivery failure to one inbox is logged and
+// skipped rather than aborting the whole fan-out — one dead follower
+// shouldn't block the rest.
+func (s *Service) PublishNote(ctx context.Context, in NoteInput) error {
+	note := NewNote(s.baseURL, in)
+	activity := NewCreateActivity(note)
+
+	body, err := json.Marshal(activity)
+	if err != nil {
+		return fmt.Errorf("marshal create activity: %w", err)
+	}
+
+	privatePEM, _, err := s.keys.GetOrCreateKeyPair(in.UserID)
+	if err != nil {
+		return fmt.Errorf("load actor key: %w", err)
+	}
+	privateKey, err := ParsePrivateKey(privatePEM)
+	if err != nil {
+		return fmt.Errorf("parse actor key: %w", err)
+	}
+
+	inboxes, err := s.followers.ListFollowerInboxes(in.UserID)
+	if err != nil {
+		return fmt.Errorf("list followers: %w", err)
+	}
+
+	keyID := ActorURL(s.baseURL, in.UserID) + "#main-key"
+	for _, inbox := range inboxes {
+		if err := s.deliver(ctx, inbox, keyID, privateKey, body); err != nil {
+			log.Printf("⚠️ Federation: failed to deliver Note to %s: %v", inbox, err)
+		}
+	}
+
+	s.emitFederatedEvent(ctx, note, len(inboxes))
+	return nil
+}
+
+func (s *Service) emitFederatedEvent(ctx context.Context, note *Note, followerCount int) {
+	if s.eventClient == nil {
+		return
+	}
+	if _, err := s.eventClient.PublishEvent(ctx, &eventpb.PublishEventRequest{
+		AggregateId:   note.ID,
+		AggregateType: "Post",
+		EventType:     "post.federated",
+		EventData:     fmt.Sprintf(`{"noteId":%q,"attributedTo":%q}`, note.ID, note.AttributedTo),
+		Metadata:      fmt.Sprintf(`{"followers":%d}`, followerCount),
+	}); err != nil {
+		log.Printf("⚠️ Federation: failed to publish post.federated event: %v", err)
+	}
+}
+
+func (s *Service) deliver(ctx context.Context, inbox, keyID string, privateKey *rsa.PrivateKey, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, inbox, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", `application/ld+json; profile="https://www.w3.org/ns/activitystreams"`)
+
+	if err := SignRequest(req, keyID, privateKey, body); err != nil {
+		return err
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("inbox %s responded with status %d", inbox, resp.StatusCode)
+	}
+	return nil
+}
+
+// resolveInbox dereferences a remote actor's profile to find its inbox
+// URL, the way a Follow handshake requires before we can deliver to them.
+func (s *Service) resolveInbox(actorURL string) (string, error) {
+	var remote struct {
+		Inbox string `json:"inbox"`
+	}
+	if err := s.fetchActorDocument(actorURL, &remote); err != nil {
+		return "", err
+	}
+	if remote.Inbox == "" {
+		return "", fmt.Errorf("actor %s has no inbox", actorURL)
+	}
+	return remote.Inbox, nil
+}
+
+// VerifyRequest checks an inbound inbox POST's Signature header - including
+// that its Digest header matches body, the actual bytes received - against
+// the sending actor's published public key.
+func (s *Service) VerifyRequest(r *http.Request, actorURL string, body []byte) error {
+	var remote struct {
+		PublicKey struct {
+			PublicKeyPem string `json:"publicKeyPem"`
+		} `json:"publicKey"`
+	}
+	if err := s.fetchActorDocument(actorURL, &remote); err != nil {
+		return fmt.Errorf("resolve actor public key: %w", err)
+	}
+
+	publicKey, err := ParsePublicKey(remote.PublicKey.PublicKeyPem)
+	if err != nil {
+		return fmt.Errorf("parse actor public key: %w", err)
+	}
+
+	return VerifyRequest(r, publicKey, body)
+}
+
+func (s *Service) fetchActorDocument(actorURL string, out interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, actorURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", `application/ld+json; profile="https://www.w3.org/ns/activitystreams"`)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("fetch actor %s: %w", actorURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("actor %s responded with status %d", actorURL, resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode actor %s: %w", actorURL, err)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+// post-service/federation/webfinger.go
+package federation
+
+import "fmt"
+
+// WebFingerResponse is the JRD document served at /.well-known/webfinger —
+// the discovery hop every remote server performs to turn an
+// "acct:user@host" handle into this actor's document URL.
+type WebFingerResponse struct {
+	Subject string          `json:"subject"`
+	Links   []WebFingerLink `json:"links"`
+}
+
+// WebFingerLink points a WebFinger subject at one representation of it.
+type WebFingerLink struct {
+	Rel  string `json:"rel"`
+	Type string `json:"type"`
+	Href string `json:"href"`
+}
+
+// NewWebFingerResponse builds the JRD for userID under host.
+func NewWebFingerResponse(baseURL, host, userID string) *WebFingerResponse {
+	return &WebFingerResponse{
+		Subject: fmt.Sprintf("acct:%s@%s", userID, host),
+		Links: []WebFingerLink{
+			{
+				Rel:  "self",
+				Type: "application/activity+json",
+				Href: ActorURL(baseURL, userID),
+			},
+		},
+	}
+}
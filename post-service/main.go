@@ -36,10 +36,17 @@ func main() {
 	}
 
 	// Auto-migrate the schema
-	err = db.AutoMigrate(&models.Post{})
+	err = db.AutoMigrate(&models.Post{}, &models.OutboxEvent{}, &models.Attachment{}, &models.IdempotencyKey{}, &models.ActorKeyPair{}, &models.Follower{}, &models.EventConsumerCursor{})
 	if err != nil {
 		log.Fatalf("❌ Failed to migrate database: %v", err)
 	}
 
+	// Add the generated full-text search column/index GORM can't express
+	if cfg.SearchBackend == "postgres" {
+		if err := database.EnsureSearchIndex(db); err != nil {
+			log.Fatalf("❌ Failed to set up search index: %v", err)
+		}
+	}
+
 	server.StartGRPCServer(cfg, db)
 }
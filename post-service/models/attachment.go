@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// AttachmentKind is the media type of an Attachment.
+type AttachmentKind string
+
+const (
+	AttachmentKindImage AttachmentKind = "image"
+	AttachmentKindVideo AttachmentKind = "video"
+	AttachmentKindFile  AttachmentKind = "file"
+)
+
+// Attachment is a piece of media uploaded to object storage and linked to a
+// post. A post can have any number of attachments, ordered by Position.
+// PostID is nullable: an attachment can be uploaded ahead of the post it
+// will belong to (e.g. while a draft is still being written) and attached
+// afterwards via AttachAttachments.
+type Attachment struct {
+	ID             uint           `json:"id" gorm:"primaryKey;autoIncrement"`
+	PostID         *uint          `json:"post_id" gorm:"index"`
+	UserID         string         `json:"user_id" gorm:"not null;index"`
+	Kind           AttachmentKind `json:"kind" gorm:"not null;type:varchar(20)"`
+	MimeType       string         `json:"mime_type" gorm:"type:varchar(100)"`
+	Size           int64          `json:"size"`
+	Width          int            `json:"width"`
+	Height         int            `json:"height"`
+	StorageKey     string         `json:"storage_key" gorm:"not null;type:text"`
+	ChecksumSHA256 string         `json:"checksum_sha256" gorm:"type:varchar(64)"`
+	Position       int            `json:"position" gorm:"default:0"`
+	CreatedAt      time.Time      `json:"created_at"`
+}
+
+func (Attachment) TableName() string {
+	return "attachments"
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// EventConsumerCursor tracks the last event ID a consumer group has
+// successfully ACKed, so a restarted clients.EventConsumer resumes the
+// stream instead of replaying the whole event log or silently skipping
+// events it never saw.
+type EventConsumerCursor struct {
+	ID            uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	ConsumerGroup string    `json:"consumer_group" gorm:"not null;uniqueIndex"`
+	LastEventID   uint64    `json:"last_event_id" gorm:"not null;default:0"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+func (EventConsumerCursor) TableName() string {
+	return "event_consumer_cursors"
+}
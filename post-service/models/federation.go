@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// ActorKeyPair is the RSA keypair generated for a local user's ActivityPub
+// actor, used to sign outgoing activities; its public half is published on
+// the actor document so remote servers can verify them.
+type ActorKeyPair struct {
+	ID         uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID     string    `json:"user_id" gorm:"not null;uniqueIndex"`
+	PrivateKey string    `json:"-" gorm:"type:text;not null"`
+	PublicKey  string    `json:"public_key" gorm:"type:text;not null"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (ActorKeyPair) TableName() string {
+	return "actor_key_pairs"
+}
+
+// Follower is a remote ActivityPub actor following a local user's actor.
+type Follower struct {
+	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID    string    `json:"user_id" gorm:"not null;uniqueIndex:idx_follower_user_actor"`
+	ActorURL  string    `json:"actor_url" gorm:"not null;uniqueIndex:idx_follower_user_actor"`
+	InboxURL  string    `json:"inbox_url" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (Follower) TableName() string {
+	return "followers"
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// IdempotencyKey records the outcome of a mutating RPC call keyed by a
+// client-supplied Idempotency-Key, so a retried request (network hiccup,
+// gRPC deadline retry) replays the cached response instead of re-running
+// the mutation. The (Key, UserID, Method) triple is unique: the same key
+// reused by a different user or against a different RPC is a different
+// logical call.
+type IdempotencyKey struct {
+	ID           uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	Key          string    `json:"key" gorm:"not null;uniqueIndex:idx_idempotency_key_user_method"`
+	UserID       string    `json:"user_id" gorm:"uniqueIndex:idx_idempotency_key_user_method"`
+	Method       string    `json:"method" gorm:"not null;uniqueIndex:idx_idempotency_key_user_method"`
+	RequestHash  string    `json:"request_hash" gorm:"not null"`
+	ResponseData string    `json:"response_data" gorm:"type:text"`
+	StatusCode   uint32    `json:"status_code"`
+	CreatedAt    time.Time `json:"created_at"`
+	ExpiresAt    time.Time `json:"expires_at" gorm:"index"`
+}
+
+func (IdempotencyKey) TableName() string {
+	return "idempotency_keys"
+}
+
+// IsExpired reports whether the TTL sweeper should reclaim this row.
+func (k IdempotencyKey) IsExpired(now time.Time) bool {
+	return now.After(k.ExpiresAt)
+}
@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// OutboxEvent is a durable record of a domain event that still needs to be
+// published to the event-service. Writing it in the same transaction as the
+// aggregate mutation it describes guarantees the event is never lost even if
+// the process crashes before the publish RPC is made.
+type OutboxEvent struct {
+	ID            uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	AggregateID   string     `json:"aggregate_id" gorm:"not null;index"`
+	AggregateType string     `json:"aggregate_type" gorm:"not null;type:varchar(100)"`
+	EventType     string     `json:"event_type" gorm:"not null;type:varchar(100)"`
+	EventData     string     `json:"event_data" gorm:"type:text"`
+	Metadata      string     `json:"metadata" gorm:"type:text"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	PublishedAt   *time.Time `json:"published_at"`
+	Attempts      uint       `json:"attempts" gorm:"default:0"`
+	LastError     string     `json:"last_error" gorm:"type:text"`
+	// ClaimedAt marks a row as owned by whichever dispatcher instance's
+	// FetchUnpublished last selected it, so a second instance's SELECT ...
+	// FOR UPDATE SKIP LOCKED doesn't pick it up again the moment the first
+	// instance's locking transaction commits. A claim older than the
+	// dispatcher's claim TTL is treated as abandoned (the owning instance
+	// presumably crashed) and becomes eligible again.
+	ClaimedAt *time.Time `json:"claimed_at"`
+}
+
+func (OutboxEvent) TableName() string {
+	return "outbox_events"
+}
+
+// IsPublished reports whether the event has already been delivered.
+func (e OutboxEvent) IsPublished() bool {
+	return e.PublishedAt != nil
+}
@@ -0,0 +1,194 @@
+// post-service/repository/attachment_repository.go
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"post-service/models"
+
+	"gorm.io/gorm"
+)
+
+type AttachmentRepository interface {
+	Create(attachment *models.Attachment) error
+	// CreateWithOutbox inserts the attachment and an attachment.created
+	// outbox row in a single transaction.
+	CreateWithOutbox(attachment *models.Attachment, outboxRepo OutboxRepository) error
+	GetByID(id uint) (*models.Attachment, error)
+	ListByPost(postID uint) ([]models.Attachment, error)
+	// ListByUser returns every attachment a user owns, including
+	// not-yet-attached ones uploaded ahead of their post (PostID IS NULL).
+	ListByUser(userID string) ([]models.Attachment, error)
+	Delete(id uint) error
+	// DeleteWithOutbox deletes the attachment row - scoped to userID, like
+	// Unlink - and writes its attachment.cleanup (storage object deletion)
+	// and attachment.deleted outbox rows in the same transaction, so a
+	// crash between the DB delete and the storage delete can't leak the
+	// blob - storage cleanup is never a synchronous, best-effort side
+	// effect of the RPC.
+	DeleteWithOutbox(id uint, userID string, outboxRepo OutboxRepository) (*models.Attachment, error)
+	// DeleteByPost removes every attachment for a post within the given
+	// transaction, returning the deleted rows so the caller can schedule
+	// their storage objects for cleanup.
+	DeleteByPost(tx *gorm.DB, postID uint) ([]models.Attachment, error)
+	// AttachToPost links the caller's unattached attachments (PostID IS
+	// NULL) to postID, returning how many were actually linked.
+	AttachToPost(ids []uint, userID string, postID uint) (int64, error)
+	// Unlink clears an attachment's PostID, returning it to the
+	// not-yet-attached pool instead of deleting it.
+	Unlink(id uint, userID string) error
+}
+
+type attachmentRepository struct {
+	db *gorm.DB
+}
+
+func NewAttachmentRepository(db *gorm.DB) AttachmentRepository {
+	return &attachmentRepository{db: db}
+}
+
+func (r *attachmentRepository) Create(attachment *models.Attachment) error {
+	return r.db.Create(attachment).Error
+}
+
+func (r *attachmentRepository) CreateWithOutbox(attachment *models.Attachment, outboxRepo OutboxRepository) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(attachment).Error; err != nil {
+			return err
+		}
+		event := attachmentCreatedEvent(*attachment)
+		return outboxRepo.Create(tx, &event)
+	})
+}
+
+func (r *attachmentRepository) GetByID(id uint) (*models.Attachment, error) {
+	var attachment models.Attachment
+	err := r.db.First(&attachment, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("attachment not found")
+		}
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+func (r *attachmentRepository) ListByPost(postID uint) ([]models.Attachment, error) {
+	var attachments []models.Attachment
+	err := r.db.Where("post_id = ?", postID).Order("position ASC, created_at ASC").Find(&attachments).Error
+	return attachments, err
+}
+
+func (r *attachmentRepository) ListByUser(userID string) ([]models.Attachment, error) {
+	var attachments []models.Attachment
+	err := r.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&attachments).Error
+	return attachments, err
+}
+
+func (r *attachmentRepository) Delete(id uint) error {
+	result := r.db.Delete(&models.Attachment{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("attachment not found")
+	}
+	return nil
+}
+
+func (r *attachmentRepository) DeleteWithOutbox(id uint, userID string, outboxRepo OutboxRepository) (*models.Attachment, error) {
+	var attachment models.Attachment
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ? AND user_id = ?", id, userID).First(&attachment).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("attachment %d not found for user %s", id, userID)
+			}
+			return err
+		}
+
+		if err := tx.Delete(&models.Attachment{}, id).Error; err != nil {
+			return err
+		}
+
+		cleanupEvent := attachmentCleanupEvent(attachment)
+		if err := outboxRepo.Create(tx, &cleanupEvent); err != nil {
+			return err
+		}
+		deletedEvent := attachmentDeletedEvent(attachment)
+		return outboxRepo.Create(tx, &deletedEvent)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+func (r *attachmentRepository) DeleteByPost(tx *gorm.DB, postID uint) ([]models.Attachment, error) {
+	var attachments []models.Attachment
+	if err := tx.Where("post_id = ?", postID).Find(&attachments).Error; err != nil {
+		return nil, err
+	}
+	if len(attachments) == 0 {
+		return attachments, nil
+	}
+	if err := tx.Where("post_id = ?", postID).Delete(&models.Attachment{}).Error; err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+func (r *attachmentRepository) AttachToPost(ids []uint, userID string, postID uint) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	result := r.db.Model(&models.Attachment{}).
+		Where("id IN ? AND user_id = ? AND post_id IS NULL", ids, userID).
+		Update("post_id", postID)
+	return result.RowsAffected, result.Error
+}
+
+func (r *attachmentRepository) Unlink(id uint, userID string) error {
+	result := r.db.Model(&models.Attachment{}).
+		Where("id = ? AND user_id = ?", id, userID).
+		Update("post_id", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("attachment %d not found for user %s", id, userID)
+	}
+	return nil
+}
+
+// attachmentCreatedEvent builds the outbox row announcing a new attachment,
+// mirroring the plain-JSON style of attachmentCleanupEvent rather than the
+// versioned events envelope used for Post events — an Attachment isn't a
+// first-class aggregate with its own read model consumers yet.
+func attachmentCreatedEvent(a models.Attachment) models.OutboxEvent {
+	postID := "null"
+	if a.PostID != nil {
+		postID = fmt.Sprintf("%d", *a.PostID)
+	}
+	return models.OutboxEvent{
+		AggregateID:   fmt.Sprintf("%d", a.ID),
+		AggregateType: "Attachment",
+		EventType:     "attachment.created",
+		EventData: fmt.Sprintf(
+			`{"id":%d,"postId":%s,"userId":%q,"kind":%q,"storageKey":%q}`,
+			a.ID, postID, a.UserID, a.Kind, a.StorageKey,
+		),
+	}
+}
+
+// attachmentDeletedEvent builds the outbox row announcing an attachment's
+// removal, emitted alongside attachmentCleanupEvent wherever an attachment
+// row is actually deleted (as opposed to merely unlinked from a post).
+func attachmentDeletedEvent(a models.Attachment) models.OutboxEvent {
+	return models.OutboxEvent{
+		AggregateID:   fmt.Sprintf("%d", a.ID),
+		AggregateType: "Attachment",
+		EventType:     "attachment.deleted",
+		EventData:     fmt.Sprintf(`{"id":%d,"userId":%q}`, a.ID, a.UserID),
+	}
+}
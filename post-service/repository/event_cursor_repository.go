@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"errors"
+
+	event_client "post-service/clients/event_client"
+	"post-service/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// EventCursorRepository persists each consumer group's last-ACKed event
+// ID. It implements event_client.CursorStore so clients.EventConsumer
+// itself never depends on gorm or database models.
+type EventCursorRepository interface {
+	event_client.CursorStore
+}
+
+type eventCursorRepository struct {
+	db *gorm.DB
+}
+
+func NewEventCursorRepository(db *gorm.DB) EventCursorRepository {
+	return &eventCursorRepository{db: db}
+}
+
+func (r *eventCursorRepository) LoadCursor(group string) (uint64, error) {
+	var cursor models.EventConsumerCursor
+	err := r.db.Where("consumer_group = ?", group).First(&cursor).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return cursor.LastEventID, nil
+}
+
+func (r *eventCursorRepository) SaveCursor(group string, eventID uint64) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "consumer_group"}},
+		DoUpdates: clause.AssignmentColumns([]string{"last_event_id", "updated_at"}),
+	}).Create(&models.EventConsumerCursor{
+		ConsumerGroup: group,
+		LastEventID:   eventID,
+	}).Error
+}
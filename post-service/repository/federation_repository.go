@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"errors"
+
+	"post-service/federation"
+	"post-service/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FederationRepository persists ActivityPub actor keys and followers. It
+// implements federation.KeyStore and federation.FollowerStore so the
+// federation package itself never depends on gorm or database models.
+type FederationRepository interface {
+	federation.KeyStore
+	federation.FollowerStore
+}
+
+type federationRepository struct {
+	db *gorm.DB
+}
+
+func NewFederationRepository(db *gorm.DB) FederationRepository {
+	return &federationRepository{db: db}
+}
+
+func (r *federationRepository) GetOrCreateKeyPair(userID string) (string, string, error) {
+	var existing models.ActorKeyPair
+	err := r.db.Where("user_id = ?", userID).First(&existing).Error
+	if err == nil {
+		return existing.PrivateKey, existing.PublicKey, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", "", err
+	}
+
+	privatePEM, publicPEM, err := federation.GenerateKeyPair()
+	if err != nil {
+		return "", "", err
+	}
+
+	keyPair := models.ActorKeyPair{UserID: userID, PrivateKey: privatePEM, PublicKey: publicPEM}
+	// Another request may have created the keypair between our lookup and
+	// here; on conflict, fall through and fetch the winner instead of
+	// erroring out.
+	if err := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&keyPair).Error; err != nil {
+		return "", "", err
+	}
+	if keyPair.ID == 0 {
+		if err := r.db.Where("user_id = ?", userID).First(&keyPair).Error; err != nil {
+			return "", "", err
+		}
+	}
+
+	return keyPair.PrivateKey, keyPair.PublicKey, nil
+}
+
+func (r *federationRepository) AddFollower(userID, followerInbox, followerActor string) error {
+	follower := models.Follower{UserID: userID, ActorURL: followerActor, InboxURL: followerInbox}
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&follower).Error
+}
+
+func (r *federationRepository) RemoveFollower(userID, followerActor string) error {
+	return r.db.Where("user_id = ? AND actor_url = ?", userID, followerActor).Delete(&models.Follower{}).Error
+}
+
+func (r *federationRepository) ListFollowerInboxes(userID string) ([]string, error) {
+	var followers []models.Follower
+	if err := r.db.Where("user_id = ?", userID).Find(&followers).Error; err != nil {
+		return nil, err
+	}
+	inboxes := make([]string, 0, len(followers))
+	for _, f := range followers {
+		inboxes = append(inboxes, f.InboxURL)
+	}
+	return inboxes, nil
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"post-service/models"
+
+	"gorm.io/gorm"
+)
+
+// IdempotencyRepository persists the outcome of idempotency-key-guarded RPC
+// calls so retries can be detected and replayed instead of re-executed.
+type IdempotencyRepository interface {
+	// Find returns the stored record for (key, userID, method), or
+	// (nil, nil) if no such call has been recorded yet.
+	Find(key, userID, method string) (*models.IdempotencyKey, error)
+	// Save persists the outcome of a call that wasn't already cached.
+	Save(record *models.IdempotencyKey) error
+	// DeleteExpired removes every record whose TTL has passed, returning
+	// how many rows were swept.
+	DeleteExpired(now time.Time) (int64, error)
+}
+
+type idempotencyRepository struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyRepository(db *gorm.DB) IdempotencyRepository {
+	return &idempotencyRepository{db: db}
+}
+
+func (r *idempotencyRepository) Find(key, userID, method string) (*models.IdempotencyKey, error) {
+	var record models.IdempotencyKey
+	err := r.db.Where("key = ? AND user_id = ? AND method = ?", key, userID, method).First(&record).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+func (r *idempotencyRepository) Save(record *models.IdempotencyKey) error {
+	return r.db.Create(record).Error
+}
+
+func (r *idempotencyRepository) DeleteExpired(now time.Time) (int64, error) {
+	result := r.db.Where("expires_at < ?", now).Delete(&models.IdempotencyKey{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,151 @@
+// post-service/repository/outbox_repository.go
+package repository
+
+import (
+	"time"
+
+	"post-service/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// OutboxRepository persists and drains the transactional outbox. Writes go
+// through the caller's transaction so the outbox row commits atomically with
+// the aggregate mutation it records; reads are used by the OutboxDispatcher
+// to find and lock work to publish.
+type OutboxRepository interface {
+	// Create inserts an outbox row using the supplied transaction.
+	Create(tx *gorm.DB, event *models.OutboxEvent) error
+	// FetchUnpublished locks up to limit unpublished, unclaimed rows
+	// (oldest first) using SELECT ... FOR UPDATE SKIP LOCKED and stamps
+	// them claimed before the locking transaction commits, so multiple
+	// dispatcher instances can drain the outbox concurrently without
+	// double-publishing: the claim is durable (and visible to the next
+	// instance's SKIP LOCKED select) the moment this call returns, well
+	// before this instance has actually published anything.
+	FetchUnpublished(limit int) ([]models.OutboxEvent, error)
+	MarkPublished(id uint) error
+	MarkFailed(id uint, lastErr string) error
+	// ReleaseClaim clears a row's claim without touching its
+	// attempts/last_error, for a dispatcher that fetched the row but chose
+	// not to act on it yet (e.g. its backoff window hasn't elapsed). Unlike
+	// MarkFailed, this isn't a failed attempt, so UpdatedAt - which backoff
+	// is timed from - must be left alone too.
+	ReleaseClaim(id uint) error
+	// OldestUnpublishedAge returns how long the oldest unpublished event has
+	// been waiting, used to report outbox lag.
+	OldestUnpublishedAge() (time.Duration, error)
+	// Replay resets every event from fromID onward back to unpublished so
+	// the dispatcher re-emits them, for rebuilding a downstream read model
+	// that has fallen out of sync.
+	Replay(fromID uint) (int64, error)
+}
+
+// outboxClaimTTL bounds how long a claimed-but-unpublished row is left
+// alone before another dispatcher instance is allowed to pick it up,
+// covering the case where the instance that claimed it crashed before
+// publishing or marking it failed.
+const outboxClaimTTL = time.Minute
+
+type outboxRepository struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) OutboxRepository {
+	return &outboxRepository{db: db}
+}
+
+func (r *outboxRepository) Create(tx *gorm.DB, event *models.OutboxEvent) error {
+	return tx.Create(event).Error
+}
+
+func (r *outboxRepository) FetchUnpublished(limit int) ([]models.OutboxEvent, error) {
+	var events []models.OutboxEvent
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		staleBefore := time.Now().UTC().Add(-outboxClaimTTL)
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("published_at IS NULL AND (claimed_at IS NULL OR claimed_at < ?)", staleBefore).
+			Order("id ASC").
+			Limit(limit).
+			Find(&events).Error; err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+
+		ids := make([]uint, len(events))
+		for i, evt := range events {
+			ids[i] = evt.ID
+		}
+
+		now := time.Now().UTC()
+		// UpdateColumn (not Update/Updates) so this doesn't also bump
+		// UpdatedAt - OutboxDispatcher's backoff is timed from the last
+		// failure via UpdatedAt, and claiming a row isn't a failure.
+		if err := tx.Model(&models.OutboxEvent{}).
+			Where("id IN ?", ids).
+			UpdateColumn("claimed_at", &now).Error; err != nil {
+			return err
+		}
+		for i := range events {
+			events[i].ClaimedAt = &now
+		}
+		return nil
+	})
+
+	return events, err
+}
+
+func (r *outboxRepository) MarkPublished(id uint) error {
+	now := time.Now().UTC()
+	return r.db.Model(&models.OutboxEvent{}).
+		Where("id = ?", id).
+		Update("published_at", &now).Error
+}
+
+func (r *outboxRepository) MarkFailed(id uint, lastErr string) error {
+	return r.db.Model(&models.OutboxEvent{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"attempts":   gorm.Expr("attempts + ?", 1),
+			"last_error": lastErr,
+			// Release the claim immediately instead of waiting out
+			// outboxClaimTTL: OutboxDispatcher already gates the actual
+			// retry on its own per-attempt backoff once the row is
+			// fetched again.
+			"claimed_at": nil,
+		}).Error
+}
+
+func (r *outboxRepository) ReleaseClaim(id uint) error {
+	return r.db.Model(&models.OutboxEvent{}).
+		Where("id = ?", id).
+		UpdateColumn("claimed_at", nil).Error
+}
+
+func (r *outboxRepository) Replay(fromID uint) (int64, error) {
+	result := r.db.Model(&models.OutboxEvent{}).
+		Where("id >= ?", fromID).
+		Updates(map[string]interface{}{
+			"published_at": nil,
+			"attempts":     0,
+			"last_error":   "",
+			"claimed_at":   nil,
+		})
+	return result.RowsAffected, result.Error
+}
+
+func (r *outboxRepository) OldestUnpublishedAge() (time.Duration, error) {
+	var oldest models.OutboxEvent
+	err := r.db.Where("published_at IS NULL").Order("id ASC").First(&oldest).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return time.Since(oldest.CreatedAt), nil
+}
@@ -2,20 +2,38 @@
 package repository
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"post-service/models"
+	"time"
 
 	"gorm.io/gorm"
 )
 
+// ErrNoPostsMatched is returned by DeletePostsWithOutbox when ids/userIds
+// matched zero posts. Callers for whom that's an expected, non-error
+// outcome (e.g. cascading from a deleted user who never authored a post)
+// should check for it with errors.Is instead of treating it as a failure.
+var ErrNoPostsMatched = errors.New("no posts found for the given criteria")
+
 type PostRepository interface {
 	Create(post *models.Post) error
+	// CreateWithOutbox inserts the post and the outbox row describing its
+	// creation event in a single transaction, so the event can never be lost
+	// even if the process dies right after the commit.
+	CreateWithOutbox(post *models.Post, event *models.OutboxEvent) error
 	GetByID(id uint) (*models.Post, error)
 	GetBySlug(slug string) (*models.Post, error)
 	Update(post *models.Post) error
+	// UpdateWithOutbox saves the post and appends an outbox row in the same
+	// transaction.
+	UpdateWithOutbox(post *models.Post, event *models.OutboxEvent) error
 	ValidateSlugUnique(slug string, excludeID uint) error
 	Delete(id uint, userID string) error
+	// DeleteWithOutbox deletes the post and appends an outbox row in the
+	// same transaction.
+	DeleteWithOutbox(id uint, userID string, event *models.OutboxEvent) error
 	List(page, limit int, category string, userID string) ([]models.Post, int64, error)
 	IncrementVisit(id uint) error
 	GetFeatured(limit int) ([]models.Post, error)
@@ -24,20 +42,210 @@ type PostRepository interface {
 	SearchPosts(query string, category string, title string, slug string, author string, sort_by string, sort_order string, page int, limit int) ([]models.Post, int64, error)
 	CountPosts(user_id, category string, is_featured bool) (int64, error)
 	DeletePosts(ids []uint32, userIds []string) error
+	// DeletePostsWithOutbox deletes the posts matching ids and/or userIds,
+	// appending one outbox row per deleted post - built by buildEvent from
+	// that post's ID, inside the same transaction - and returns the IDs
+	// actually matched and deleted. The caller must use these returned IDs
+	// for anything downstream (further events, search-index cleanup, ...)
+	// rather than its own ids argument: a userIds-only call (e.g. cascading
+	// from a deleted user) matches posts the caller never enumerated itself.
+	DeletePostsWithOutbox(ids []uint32, userIds []string, buildEvent func(postID uint) (models.OutboxEvent, error)) ([]uint32, error)
 }
 
 type postRepository struct {
-	db *gorm.DB
+	db             *gorm.DB
+	outboxRepo     OutboxRepository
+	attachmentRepo AttachmentRepository
+}
+
+func NewPostRepository(db *gorm.DB, outboxRepo OutboxRepository, attachmentRepo AttachmentRepository) PostRepository {
+	return &postRepository{db: db, outboxRepo: outboxRepo, attachmentRepo: attachmentRepo}
 }
 
-func NewPostRepository(db *gorm.DB) PostRepository {
-	return &postRepository{db: db}
+// attachmentCleanupEvent builds the outbox row that tells the
+// OutboxDispatcher to delete a now-orphaned attachment's storage object, so
+// a crash between the DB delete and the storage delete can't leak a blob.
+func attachmentCleanupEvent(a models.Attachment) models.OutboxEvent {
+	return models.OutboxEvent{
+		AggregateID:   fmt.Sprintf("%d", a.ID),
+		AggregateType: "Attachment",
+		EventType:     "attachment.cleanup",
+		EventData:     fmt.Sprintf(`{"id":%d,"storageKey":"%s"}`, a.ID, a.StorageKey),
+	}
+}
+
+// federationNotePayload is the EventData shape read by
+// OutboxDispatcher.federatePost to build the ActivityPub Note for a post.
+type federationNotePayload struct {
+	Slug      string `json:"slug"`
+	Title     string `json:"title"`
+	Content   string `json:"content"`
+	UserID    string `json:"userId"`
+	CreatedAt string `json:"createdAt"`
+}
+
+// federationOutboxEvent builds the outbox row that tells the
+// OutboxDispatcher to federate a created/updated post as an ActivityPub
+// Note, so a crash between the DB commit and the inbox deliveries can't
+// silently drop the post from followers' feeds.
+func federationOutboxEvent(post *models.Post) (models.OutboxEvent, error) {
+	data, err := json.Marshal(federationNotePayload{
+		Slug:      post.Slug,
+		Title:     post.Title,
+		Content:   post.Content,
+		UserID:    post.UserID,
+		CreatedAt: post.CreatedAt.UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return models.OutboxEvent{}, fmt.Errorf("marshal federation payload: %w", err)
+	}
+
+	return models.OutboxEvent{
+		AggregateID:   fmt.Sprintf("%d", post.ID),
+		AggregateType: "Post",
+		EventType:     "post.federate",
+		EventData:     string(data),
+	}, nil
 }
 
 func (r *postRepository) Create(post *models.Post) error {
 	return r.db.Create(post).Error
 }
 
+func (r *postRepository) CreateWithOutbox(post *models.Post, event *models.OutboxEvent) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(post).Error; err != nil {
+			return err
+		}
+		event.AggregateID = fmt.Sprintf("%d", post.ID)
+		if err := r.outboxRepo.Create(tx, event); err != nil {
+			return err
+		}
+
+		fedEvent, err := federationOutboxEvent(post)
+		if err != nil {
+			return err
+		}
+		return r.outboxRepo.Create(tx, &fedEvent)
+	})
+}
+
+func (r *postRepository) UpdateWithOutbox(post *models.Post, event *models.OutboxEvent) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(post).Error; err != nil {
+			return err
+		}
+		event.AggregateID = fmt.Sprintf("%d", post.ID)
+		if err := r.outboxRepo.Create(tx, event); err != nil {
+			return err
+		}
+
+		fedEvent, err := federationOutboxEvent(post)
+		if err != nil {
+			return err
+		}
+		return r.outboxRepo.Create(tx, &fedEvent)
+	})
+}
+
+func (r *postRepository) DeleteWithOutbox(id uint, userID string, event *models.OutboxEvent) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ? AND user_id = ?", id, userID).Delete(&models.Post{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("post not found or unauthorized")
+		}
+
+		deleted, err := r.attachmentRepo.DeleteByPost(tx, id)
+		if err != nil {
+			return err
+		}
+		for _, attachment := range deleted {
+			cleanupEvent := attachmentCleanupEvent(attachment)
+			if err := r.outboxRepo.Create(tx, &cleanupEvent); err != nil {
+				return err
+			}
+			deletedEvent := attachmentDeletedEvent(attachment)
+			if err := r.outboxRepo.Create(tx, &deletedEvent); err != nil {
+				return err
+			}
+		}
+
+		event.AggregateID = fmt.Sprintf("%d", id)
+		return r.outboxRepo.Create(tx, event)
+	})
+}
+
+func (r *postRepository) DeletePostsWithOutbox(ids []uint32, userIds []string, buildEvent func(postID uint) (models.OutboxEvent, error)) ([]uint32, error) {
+	if len(ids) == 0 && len(userIds) == 0 {
+		return nil, errors.New("either ids or userIds must be provided")
+	}
+
+	var deletedIDs []uint32
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var matched []models.Post
+		matchQuery := tx.Model(&models.Post{})
+		if len(ids) > 0 {
+			matchQuery = matchQuery.Where("id IN ?", ids)
+		}
+		if len(userIds) > 0 {
+			matchQuery = matchQuery.Where("user_id IN ?", userIds)
+		}
+		if err := matchQuery.Find(&matched).Error; err != nil {
+			return err
+		}
+		if len(matched) == 0 {
+			return ErrNoPostsMatched
+		}
+
+		matchedIDs := make([]uint32, len(matched))
+		for i, post := range matched {
+			matchedIDs[i] = uint32(post.ID)
+		}
+
+		// Delete exactly the rows we just matched, rather than re-deriving
+		// the same WHERE clause, so the set this transaction acts on can
+		// never drift between the two queries.
+		if err := tx.Where("id IN ?", matchedIDs).Delete(&models.Post{}).Error; err != nil {
+			return err
+		}
+
+		for _, post := range matched {
+			deleted, err := r.attachmentRepo.DeleteByPost(tx, post.ID)
+			if err != nil {
+				return err
+			}
+			for _, attachment := range deleted {
+				cleanupEvent := attachmentCleanupEvent(attachment)
+				if err := r.outboxRepo.Create(tx, &cleanupEvent); err != nil {
+					return err
+				}
+				deletedEvent := attachmentDeletedEvent(attachment)
+				if err := r.outboxRepo.Create(tx, &deletedEvent); err != nil {
+					return err
+				}
+			}
+
+			event, err := buildEvent(post.ID)
+			if err != nil {
+				return err
+			}
+			if err := r.outboxRepo.Create(tx, &event); err != nil {
+				return err
+			}
+		}
+
+		deletedIDs = matchedIDs
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deletedIDs, nil
+}
+
 func (r *postRepository) GetByID(id uint) (*models.Post, error) {
 	var post models.Post
 	err := r.db.First(&post, id).Error
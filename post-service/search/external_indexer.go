@@ -0,0 +1,180 @@
+// post-service/search/external_indexer.go
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"post-service/models"
+)
+
+// ExternalIndexer pushes posts into an external search engine (Meilisearch
+// or OpenSearch, both of which speak a similar document-indexing HTTP API)
+// and queries it back for ranked results. Unlike PostgresIndexer, writes
+// here are a separate network call from the DB transaction, so callers
+// should route them through the outbox to survive a crash between the post
+// write and the index push.
+type ExternalIndexer struct {
+	baseURL    string
+	apiKey     string
+	indexName  string
+	httpClient *http.Client
+}
+
+func NewExternalIndexer(baseURL, apiKey, indexName string) *ExternalIndexer {
+	return &ExternalIndexer{
+		baseURL:    baseURL,
+		apiKey:     apiKey,
+		indexName:  indexName,
+		httpClient: &http.Client{},
+	}
+}
+
+type externalDocument struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Desc     string `json:"desc"`
+	Content  string `json:"content"`
+	Category string `json:"category"`
+	Slug     string `json:"slug"`
+	UserID   string `json:"user_id"`
+}
+
+func (i *ExternalIndexer) IndexPost(ctx context.Context, post *models.Post) error {
+	doc := externalDocument{
+		ID:       strconv.FormatUint(uint64(post.ID), 10),
+		Title:    post.Title,
+		Desc:     post.Desc,
+		Content:  post.Content,
+		Category: post.Category,
+		Slug:     post.Slug,
+		UserID:   post.UserID,
+	}
+
+	body, err := json.Marshal([]externalDocument{doc})
+	if err != nil {
+		return fmt.Errorf("failed to marshal search document: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/indexes/%s/documents", i.baseURL, i.indexName)
+	return i.do(ctx, http.MethodPost, url, body)
+}
+
+func (i *ExternalIndexer) DeletePost(ctx context.Context, postID uint) error {
+	url := fmt.Sprintf("%s/indexes/%s/documents/%d", i.baseURL, i.indexName, postID)
+	return i.do(ctx, http.MethodDelete, url, nil)
+}
+
+func (i *ExternalIndexer) do(ctx context.Context, method, url string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build search index request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if i.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+i.apiKey)
+	}
+
+	resp, err := i.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach search backend: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("search backend returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+type externalSearchRequest struct {
+	Query                string   `json:"q"`
+	Limit                int      `json:"limit"`
+	Offset               int      `json:"offset"`
+	Filter               []string `json:"filter,omitempty"`
+	AttributesToHighlight []string `json:"attributesToHighlight,omitempty"`
+}
+
+type externalSearchHit struct {
+	ID                string            `json:"id"`
+	RankingScore      float64           `json:"_rankingScore"`
+	Formatted         map[string]string `json:"_formatted"`
+}
+
+type externalSearchResponse struct {
+	Hits             []externalSearchHit `json:"hits"`
+	EstimatedTotalHits int64              `json:"estimatedTotalHits"`
+}
+
+func (i *ExternalIndexer) Search(ctx context.Context, q Query) (Result, error) {
+	page := q.Page
+	if page <= 0 {
+		page = 1
+	}
+	limit := q.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+
+	reqBody := externalSearchRequest{
+		Query:  q.Text,
+		Limit:  limit,
+		Offset: (page - 1) * limit,
+	}
+	if q.Category != "" && q.Category != "all" {
+		reqBody.Filter = []string{fmt.Sprintf("category = %q", q.Category)}
+	}
+	if q.Highlight {
+		reqBody.AttributesToHighlight = []string{"title", "desc", "content"}
+	}
+
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to marshal search request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/indexes/%s/search", i.baseURL, i.indexName)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to build search request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if i.apiKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+i.apiKey)
+	}
+
+	resp, err := i.httpClient.Do(httpReq)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to reach search backend: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return Result{}, fmt.Errorf("search backend returned status %d", resp.StatusCode)
+	}
+
+	var parsed externalSearchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return Result{}, fmt.Errorf("failed to decode search response: %w", err)
+	}
+
+	hits := make([]Hit, len(parsed.Hits))
+	for idx, h := range parsed.Hits {
+		id, _ := strconv.ParseUint(h.ID, 10, 64)
+		hit := Hit{PostID: uint(id), Score: h.RankingScore}
+		if q.Highlight && h.Formatted != nil {
+			hit.Highlight = Highlight{
+				Title:   h.Formatted["title"],
+				Desc:    h.Formatted["desc"],
+				Content: h.Formatted["content"],
+			}
+		}
+		hits[idx] = hit
+	}
+
+	return Result{Hits: hits, Total: parsed.EstimatedTotalHits}, nil
+}
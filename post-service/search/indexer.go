@@ -0,0 +1,65 @@
+// post-service/search/indexer.go
+package search
+
+import (
+	"context"
+
+	"post-service/models"
+)
+
+// Indexer keeps a full-text search index of posts in sync with the
+// database and serves ranked, highlighted search queries. The Postgres
+// backend indexes in place via a generated tsvector column, so IndexPost
+// and DeletePost are no-ops there; external backends (Meilisearch,
+// OpenSearch) keep a separate index that must be pushed to explicitly, and
+// are kept consistent by calling IndexPost/DeletePost from the service
+// layer on every write.
+type Indexer interface {
+	IndexPost(ctx context.Context, post *models.Post) error
+	DeletePost(ctx context.Context, postID uint) error
+	Search(ctx context.Context, q Query) (Result, error)
+}
+
+// Query describes a single search request, independent of transport.
+type Query struct {
+	Text      string
+	Category  string
+	Language  string
+	MinScore  float64
+	Highlight bool
+	Page      int
+	Limit     int
+}
+
+// Highlight holds ranked snippets for the fields the caller asked to be
+// highlighted.
+type Highlight struct {
+	Title   string
+	Desc    string
+	Content string
+}
+
+// Hit is a single ranked search result.
+type Hit struct {
+	PostID    uint
+	Score     float64
+	Highlight Highlight
+}
+
+// Result is the full response to a Search call.
+type Result struct {
+	Hits  []Hit
+	Total int64
+}
+
+// Backend selects which Indexer implementation to construct.
+type Backend string
+
+const (
+	BackendPostgres    Backend = "postgres"
+	BackendMeilisearch Backend = "meilisearch"
+	// BackendSQLite falls back to a plain LIKE query, for local dev
+	// environments running against SQLite where search_vector/tsquery/
+	// ts_rank_cd aren't available.
+	BackendSQLite Backend = "sqlite"
+)
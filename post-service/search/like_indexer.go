@@ -0,0 +1,75 @@
+// post-service/search/like_indexer.go
+package search
+
+import (
+	"context"
+	"fmt"
+
+	"post-service/models"
+
+	"gorm.io/gorm"
+)
+
+// LikeIndexer is a dependency-free fallback for local dev environments
+// (typically SQLite) that don't have the Postgres tsvector/GIN/ts_rank_cd
+// machinery PostgresIndexer relies on. It has no relevance ranking and no
+// real highlighting — just enough to keep SearchPosts working during local
+// development.
+type LikeIndexer struct {
+	db *gorm.DB
+}
+
+func NewLikeIndexer(db *gorm.DB) *LikeIndexer {
+	return &LikeIndexer{db: db}
+}
+
+func (i *LikeIndexer) IndexPost(ctx context.Context, post *models.Post) error {
+	return nil
+}
+
+func (i *LikeIndexer) DeletePost(ctx context.Context, postID uint) error {
+	return nil
+}
+
+func (i *LikeIndexer) Search(ctx context.Context, q Query) (Result, error) {
+	page := q.Page
+	if page <= 0 {
+		page = 1
+	}
+	limit := q.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+	offset := (page - 1) * limit
+
+	like := "%" + q.Text + "%"
+	base := i.db.WithContext(ctx).Model(&models.Post{}).
+		Where("title LIKE ? OR content LIKE ?", like, like)
+	if q.Category != "" && q.Category != "all" {
+		base = base.Where("category = ?", q.Category)
+	}
+
+	var total int64
+	if err := base.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return Result{}, fmt.Errorf("failed to count search results: %w", err)
+	}
+
+	var posts []models.Post
+	if err := base.Session(&gorm.Session{}).
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&posts).Error; err != nil {
+		return Result{}, fmt.Errorf("failed to run LIKE search: %w", err)
+	}
+
+	hits := make([]Hit, len(posts))
+	for idx, post := range posts {
+		hits[idx] = Hit{PostID: post.ID}
+		if q.Highlight {
+			hits[idx].Highlight = Highlight{Title: post.Title, Desc: post.Desc, Content: post.Content}
+		}
+	}
+
+	return Result{Hits: hits, Total: total}, nil
+}
@@ -0,0 +1,112 @@
+// post-service/search/postgres_indexer.go
+package search
+
+import (
+	"context"
+	"fmt"
+
+	"post-service/models"
+
+	"gorm.io/gorm"
+)
+
+// PostgresIndexer relies on the `search_vector` generated tsvector column
+// and its GIN index (see the posts migration), which Postgres maintains
+// automatically as part of every row write. IndexPost/DeletePost are
+// therefore no-ops here; the column is already current by the time this is
+// called. Search builds a tsquery from the caller's text, ranks with
+// ts_rank_cd, and highlights with ts_headline.
+type PostgresIndexer struct {
+	db *gorm.DB
+}
+
+func NewPostgresIndexer(db *gorm.DB) *PostgresIndexer {
+	return &PostgresIndexer{db: db}
+}
+
+func (i *PostgresIndexer) IndexPost(ctx context.Context, post *models.Post) error {
+	return nil
+}
+
+func (i *PostgresIndexer) DeletePost(ctx context.Context, postID uint) error {
+	return nil
+}
+
+type searchRow struct {
+	ID        uint
+	Score     float64
+	TitleHL   string
+	DescHL    string
+	ContentHL string
+}
+
+func (i *PostgresIndexer) Search(ctx context.Context, q Query) (Result, error) {
+	language := q.Language
+	if language == "" {
+		language = "english"
+	}
+
+	page := q.Page
+	if page <= 0 {
+		page = 1
+	}
+	limit := q.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+	offset := (page - 1) * limit
+
+	minScore := q.MinScore
+
+	base := i.db.WithContext(ctx).Table("posts, websearch_to_tsquery(?, ?) query", language, q.Text).
+		Where("search_vector @@ query")
+	if q.Category != "" && q.Category != "all" {
+		base = base.Where("category = ?", q.Category)
+	}
+
+	var total int64
+	if err := base.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return Result{}, fmt.Errorf("failed to count search results: %w", err)
+	}
+
+	var rows []searchRow
+	// Select takes a single query string with its own "?" placeholders
+	// bound to the args that follow it - passing several strings (one per
+	// column) leaves all but the first's placeholders unbound. Find must
+	// not be given extra positional args either: GORM treats those as a
+	// primary-key IN (...) clause, not as bind values for the Select/Where
+	// placeholders above.
+	err := base.Session(&gorm.Session{}).
+		Select(
+			"posts.id AS id, ts_rank_cd(search_vector, query) AS score, "+
+				"ts_headline(?, title, query) AS title_hl, "+
+				"ts_headline(?, \"desc\", query) AS desc_hl, "+
+				"ts_headline(?, content, query) AS content_hl",
+			language, language, language,
+		).
+		Where("ts_rank_cd(search_vector, query) >= ?", minScore).
+		Order("score DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&rows).Error
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to run full-text search: %w", err)
+	}
+
+	hits := make([]Hit, len(rows))
+	for idx, row := range rows {
+		hits[idx] = Hit{
+			PostID: row.ID,
+			Score:  row.Score,
+		}
+		if q.Highlight {
+			hits[idx].Highlight = Highlight{
+				Title:   row.TitleHL,
+				Desc:    row.DescHL,
+				Content: row.ContentHL,
+			}
+		}
+	}
+
+	return Result{Hits: hits, Total: total}, nil
+}
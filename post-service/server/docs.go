@@ -0,0 +1,38 @@
+// post-service/server/docs.go
+package server
+
+import "net/http"
+
+const openAPISpecPath = "openapi/post_service.swagger.json"
+
+// openAPIHandler serves the generated OpenAPI/Swagger document for the
+// REST gateway.
+func openAPIHandler(w http.ResponseWriter, r *http.Request) {
+	http.ServeFile(w, r, openAPISpecPath)
+}
+
+const swaggerUIPage = `<!DOCTYPE html>
+<html>
+<head>
+  <title>Post Service API Docs</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist/swagger-ui.css" />
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist/swagger-ui-bundle.js"></script>
+  <script>
+    window.onload = () => {
+      window.ui = SwaggerUIBundle({ url: "/openapi.json", dom_id: "#swagger-ui" });
+    };
+  </script>
+</body>
+</html>`
+
+// swaggerUIHandler serves a minimal Swagger UI page that renders the spec
+// at /openapi.json.
+func swaggerUIHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(swaggerUIPage))
+	}
+}
@@ -0,0 +1,138 @@
+// post-service/server/federation_handlers.go
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"post-service/federation"
+	"post-service/repository"
+)
+
+// webfingerHandler answers the acct:user@host lookup every remote server
+// performs before following an actor, pointing it at the actor document.
+func webfingerHandler(fed *federation.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := userIDFromAcctResource(r.URL.Query().Get("resource"))
+		if userID == "" {
+			http.Error(w, `{"error":"missing or invalid resource parameter"}`, http.StatusBadRequest)
+			return
+		}
+
+		resp := federation.NewWebFingerResponse(fed.BaseURL(), federation.HostFromBaseURL(fed.BaseURL()), userID)
+		w.Header().Set("Content-Type", "application/jrd+json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
+
+func userIDFromAcctResource(resource string) string {
+	resource = strings.TrimPrefix(resource, "acct:")
+	if idx := strings.Index(resource, "@"); idx != -1 {
+		return resource[:idx]
+	}
+	return resource
+}
+
+// actorsHandler dispatches /actors/{userID}, /actors/{userID}/inbox and
+// /actors/{userID}/outbox to their respective handlers.
+func actorsHandler(fed *federation.Service, postRepo repository.PostRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		segments := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/actors/"), "/"), "/")
+		if len(segments) == 0 || segments[0] == "" {
+			http.NotFound(w, r)
+			return
+		}
+		userID := segments[0]
+
+		switch {
+		case len(segments) == 1:
+			actorProfileHandler(fed, userID, w, r)
+		case len(segments) == 2 && segments[1] == "inbox":
+			actorInboxHandler(fed, userID, w, r)
+		case len(segments) == 2 && segments[1] == "outbox":
+			actorOutboxHandler(fed, postRepo, userID, w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	}
+}
+
+func actorProfileHandler(fed *federation.Service, userID string, w http.ResponseWriter, r *http.Request) {
+	actor, err := fed.Actor(userID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"failed to load actor: %v"}`, err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/activity+json")
+	_ = json.NewEncoder(w).Encode(actor)
+}
+
+// actorInboxHandler accepts Follow/Undo/Like/Create activities for userID,
+// verifying the sender's HTTP signature before acting on anything.
+func actorInboxHandler(fed *federation.Service, userID string, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, `{"error":"failed to read request body"}`, http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+
+	var activity federation.Activity
+	if err := json.Unmarshal(body, &activity); err != nil {
+		http.Error(w, `{"error":"invalid activity"}`, http.StatusBadRequest)
+		return
+	}
+
+	if err := fed.VerifyRequest(r, activity.Actor, body); err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"signature verification failed: %v"}`, err), http.StatusUnauthorized)
+		return
+	}
+
+	if err := fed.HandleInboxActivity(r.Context(), userID, activity); err != nil {
+		log.Printf("⚠️ Federation: failed to handle %s activity for %s: %v", activity.Type, userID, err)
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// actorOutboxHandler returns the user's recent posts as an
+// OrderedCollection of Notes.
+func actorOutboxHandler(fed *federation.Service, postRepo repository.PostRepository, userID string, w http.ResponseWriter, r *http.Request) {
+	const outboxPageSize = 20
+
+	posts, total, err := postRepo.GetByUser(userID, 1, outboxPageSize)
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"failed to list posts: %v"}`, err), http.StatusInternalServerError)
+		return
+	}
+
+	items := make([]*federation.Note, 0, len(posts))
+	for _, p := range posts {
+		items = append(items, federation.NewNote(fed.BaseURL(), federation.NoteInput{
+			Slug:      p.Slug,
+			Title:     p.Title,
+			Content:   p.Content,
+			UserID:    p.UserID,
+			CreatedAt: p.CreatedAt,
+		}))
+	}
+
+	collection := map[string]interface{}{
+		"@context":     federation.ActivityStreamsContext,
+		"id":           federation.ActorURL(fed.BaseURL(), userID) + "/outbox",
+		"type":         "OrderedCollection",
+		"totalItems":   total,
+		"orderedItems": items,
+	}
+	w.Header().Set("Content-Type", "application/activity+json")
+	_ = json.NewEncoder(w).Encode(collection)
+}
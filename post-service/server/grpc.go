@@ -1,6 +1,7 @@
 package server
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net"
@@ -12,18 +13,57 @@ import (
 	user_client "post-service/clients/user_client"
 
 	"post-service/config"
+	"post-service/federation"
 	"post-service/protos/postpb"
 	"post-service/repository"
+	"post-service/search"
 	"post-service/service"
+	"post-service/storage"
 
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 	"gorm.io/gorm"
 )
 
+// newIndexer selects the search.Indexer implementation based on
+// cfg.SearchBackend, defaulting to the Postgres tsvector backend.
+func newIndexer(cfg *config.Config, db *gorm.DB) search.Indexer {
+	switch search.Backend(cfg.SearchBackend) {
+	case search.BackendMeilisearch:
+		return search.NewExternalIndexer(cfg.SearchAddress, cfg.SearchAPIKey, cfg.SearchIndex)
+	case search.BackendSQLite:
+		return search.NewLikeIndexer(db)
+	default:
+		return search.NewPostgresIndexer(db)
+	}
+}
+
+// newStorageBackend selects the storage.Backend implementation based on
+// cfg.StorageBackend, defaulting to local disk.
+func newStorageBackend(cfg *config.Config) storage.Backend {
+	if cfg.StorageBackend == "s3" {
+		awsCfg, err := awsconfig.LoadDefaultConfig(context.Background(), awsconfig.WithRegion(cfg.S3Region))
+		if err != nil {
+			log.Fatalf("❌ Failed to load AWS config for S3 storage backend: %v", err)
+		}
+		client := s3.NewFromConfig(awsCfg)
+		return storage.NewS3Backend(client, cfg.S3Bucket, cfg.StoragePublicURL)
+	}
+	return storage.NewLocalBackend(cfg.StorageBaseDir, cfg.StoragePublicURL, cfg.StorageSignKey)
+}
+
 func StartGRPCServer(cfg *config.Config, db *gorm.DB) {
-	// Initialize repository
-	postRepo := repository.NewPostRepository(db)
+	// Initialize repositories
+	outboxRepo := repository.NewOutboxRepository(db)
+	attachmentRepo := repository.NewAttachmentRepository(db)
+	idempotencyRepo := repository.NewIdempotencyRepository(db)
+	federationRepo := repository.NewFederationRepository(db)
+	eventCursorRepo := repository.NewEventCursorRepository(db)
+	postRepo := repository.NewPostRepository(db, outboxRepo, attachmentRepo)
+	indexer := newIndexer(cfg, db)
+	storageBackend := newStorageBackend(cfg)
 
 	eventClient, err := event_client.NewEventServiceClient(cfg.EventServiceAddress)
 	if err != nil {
@@ -45,11 +85,37 @@ func StartGRPCServer(cfg *config.Config, db *gorm.DB) {
 		}
 	}()
 
-	// Initialize service
-	postService := service.NewPostServiceServer(postRepo, eventClient, userClient)
+	// federationService delivers Create(Note) activities to followers'
+	// inboxes and answers WebFinger/actor lookups for this instance.
+	federationService := federation.NewService(cfg.FederationBaseURL, federationRepo, federationRepo, eventClient)
 
-	// Create gRPC server
-	grpcServer := grpc.NewServer()
+	// Initialize service
+	postService := service.NewPostServiceServer(postRepo, outboxRepo, eventClient, userClient, indexer, attachmentRepo, storageBackend)
+
+	// Start the outbox dispatcher that drains outbox_events and publishes
+	// them to the event service (cleans up deleted attachments' storage
+	// objects, and delivers ActivityPub Create(Note) activities to
+	// followers), independently of the request path.
+	outboxDispatcher := service.NewOutboxDispatcher(outboxRepo, eventClient, storageBackend, federationService)
+	outboxDispatcher.Start()
+
+	// Start the sweeper that reclaims expired idempotency_keys rows.
+	idempotencySweeper := service.NewIdempotencySweeper(idempotencyRepo)
+	idempotencySweeper.Start()
+
+	// Start the event consumer that reacts to upstream domain events. It
+	// currently only cascades user.deleted onto this user's posts, but
+	// new behavior is added by registering more handlers via On.
+	eventConsumer := event_client.NewEventConsumer(eventClient, eventCursorRepo, "post-service", []string{"user.deleted"})
+	eventConsumer.On("user.deleted", postService.HandleUserDeleted)
+	eventConsumer.Start()
+
+	// Create gRPC server. IdempotencyMiddleware guards CreatePost,
+	// UpdatePost, PatchPost, DeletePost, DeletePosts and IncrementVisit
+	// against duplicate execution on client retry.
+	grpcServer := grpc.NewServer(
+		grpc.UnaryInterceptor(service.IdempotencyMiddleware(idempotencyRepo)),
+	)
 
 	// Register gRPC service
 	postpb.RegisterPostServiceServer(grpcServer, postService)
@@ -76,11 +142,20 @@ func StartGRPCServer(cfg *config.Config, db *gorm.DB) {
 		}
 	}()
 
+	// Run the REST gateway alongside gRPC, sharing the same signal handler
+	// for graceful shutdown.
+	httpGateway := NewHTTPGateway(cfg, federationService, postRepo, storageBackend)
+	httpGateway.Start()
+
 	// Wait for shutdown signal
 	<-stop
 	log.Println("\n🛑 Shutting down Post gRPC server...")
 
 	// Graceful stop
 	grpcServer.GracefulStop()
+	httpGateway.Stop()
+	outboxDispatcher.Stop()
+	idempotencySweeper.Stop()
+	eventConsumer.Stop()
 	log.Println("✅ Post gRPC server stopped gracefully")
 }
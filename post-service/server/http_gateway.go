@@ -0,0 +1,92 @@
+// post-service/server/http_gateway.go
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"post-service/config"
+	"post-service/federation"
+	"post-service/protos/postpb"
+	"post-service/repository"
+	"post-service/storage"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// HTTPGateway proxies REST/JSON requests to the in-process gRPC server via
+// grpc-gateway, so web/mobile clients can use the API without gRPC
+// tooling. The REST routes themselves come from the google.api.http
+// annotations on the post-service protos. It also hosts the ActivityPub
+// federation endpoints (WebFinger discovery and per-actor inbox/outbox),
+// since both are plain HTTP/JSON and share this same listener.
+type HTTPGateway struct {
+	cfg        *config.Config
+	federation *federation.Service
+	postRepo   repository.PostRepository
+	storage    storage.Backend
+	httpServer *http.Server
+}
+
+func NewHTTPGateway(cfg *config.Config, federationService *federation.Service, postRepo repository.PostRepository, storageBackend storage.Backend) *HTTPGateway {
+	return &HTTPGateway{cfg: cfg, federation: federationService, postRepo: postRepo, storage: storageBackend}
+}
+
+// Start dials the local gRPC server, registers the gateway mux, and begins
+// serving HTTP in a background goroutine.
+func (g *HTTPGateway) Start() {
+	grpcEndpoint := fmt.Sprintf("localhost:%s", g.cfg.GRPCPort)
+
+	gwMux := runtime.NewServeMux()
+	dialOpts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	if err := postpb.RegisterPostServiceHandlerFromEndpoint(context.Background(), gwMux, grpcEndpoint, dialOpts); err != nil {
+		log.Fatalf("❌ Failed to register HTTP gateway: %v", err)
+	}
+
+	topMux := http.NewServeMux()
+	topMux.HandleFunc("/healthz", healthzHandler)
+	topMux.HandleFunc("/readyz", readyzHandler(grpcEndpoint))
+	topMux.HandleFunc("/openapi.json", openAPIHandler)
+	topMux.HandleFunc("/docs", swaggerUIHandler())
+	topMux.Handle("/metrics", promhttp.Handler())
+	topMux.HandleFunc("/.well-known/webfinger", webfingerHandler(g.federation))
+	topMux.HandleFunc("/actors/", actorsHandler(g.federation, g.postRepo))
+	// LocalBackend's PresignUpload URLs point back at this gateway; S3's
+	// point straight at the bucket, so there's nothing to serve here for
+	// that backend.
+	if local, ok := g.storage.(*storage.LocalBackend); ok {
+		topMux.HandleFunc("/uploads/", localUploadHandler(local))
+	}
+	topMux.Handle("/", gwMux)
+
+	handler := recoverMiddleware(corsMiddleware(loggingMiddleware(topMux)))
+
+	address := fmt.Sprintf(":%s", g.cfg.HTTPPort)
+	g.httpServer = &http.Server{Addr: address, Handler: handler}
+
+	go func() {
+		log.Printf("🌐 Post HTTP gateway running on %s", address)
+		if err := g.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("❌ Failed to serve HTTP gateway: %v", err)
+		}
+	}()
+}
+
+// Stop gracefully shuts down the HTTP gateway, sharing the caller's signal
+// handler with the gRPC server.
+func (g *HTTPGateway) Stop() {
+	if g.httpServer == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := g.httpServer.Shutdown(ctx); err != nil {
+		log.Printf("⚠️ HTTP gateway did not shut down cleanly: %v", err)
+	}
+}
@@ -0,0 +1,48 @@
+// post-service/server/local_upload_handler.go
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"post-service/storage"
+)
+
+// localUploadHandler serves the PUT endpoint LocalBackend.PresignUpload
+// hands out pre-signed URLs for, verifying the HMAC token before writing
+// the request body to disk. S3Backend needs no equivalent - its pre-signed
+// URLs point straight at the bucket.
+func localUploadHandler(backend *storage.LocalBackend) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		key := strings.TrimPrefix(r.URL.Path, "/uploads/")
+		if key == "" {
+			http.Error(w, "missing upload key", http.StatusBadRequest)
+			return
+		}
+
+		expiresAt, err := strconv.ParseInt(r.URL.Query().Get("expires"), 10, 64)
+		if err != nil {
+			http.Error(w, "invalid or missing expires", http.StatusBadRequest)
+			return
+		}
+
+		if !backend.VerifyUploadToken(key, expiresAt, r.URL.Query().Get("token")) {
+			http.Error(w, "invalid or expired upload token", http.StatusForbidden)
+			return
+		}
+
+		if err := backend.Put(r.Context(), key, r.Body, r.ContentLength, r.Header.Get("Content-Type")); err != nil {
+			http.Error(w, fmt.Sprintf("failed to store upload: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
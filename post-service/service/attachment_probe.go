@@ -0,0 +1,81 @@
+// post-service/service/attachment_probe.go
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// probeImageDimensions reads just enough of data to decode its image header
+// and return its pixel dimensions, without decoding the full image.
+func probeImageDimensions(data []byte) (width, height int, err error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return 0, 0, fmt.Errorf("decode image header: %w", err)
+	}
+	return cfg.Width, cfg.Height, nil
+}
+
+// VideoProber probes a video file for its pixel dimensions. The default
+// implementation shells out to ffprobe; tests can substitute a fake.
+type VideoProber interface {
+	Probe(path string) (width, height int, err error)
+}
+
+// FFProbeVideoProber probes video dimensions via the ffprobe CLI, the way
+// paopao-ce's media pipeline does rather than pulling in a cgo video
+// decoding library.
+type FFProbeVideoProber struct{}
+
+func (FFProbeVideoProber) Probe(path string) (width, height int, err error) {
+	out, err := exec.Command(
+		"ffprobe",
+		"-v", "error",
+		"-select_streams", "v:0",
+		"-show_entries", "stream=width,height",
+		"-of", "csv=s=x:p=0",
+		path,
+	).Output()
+	if err != nil {
+		return 0, 0, fmt.Errorf("ffprobe: %w", err)
+	}
+
+	dims := strings.SplitN(strings.TrimSpace(string(out)), "x", 2)
+	if len(dims) != 2 {
+		return 0, 0, fmt.Errorf("ffprobe: unexpected output %q", out)
+	}
+
+	width, err = strconv.Atoi(dims[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("ffprobe: parse width: %w", err)
+	}
+	height, err = strconv.Atoi(dims[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("ffprobe: parse height: %w", err)
+	}
+	return width, height, nil
+}
+
+// writeTempVideo spills data to a temp file so ffprobe (which needs a
+// seekable path, not a stream) can read it, and returns a cleanup func.
+func writeTempVideo(data []byte, suffix string) (path string, cleanup func(), err error) {
+	f, err := os.CreateTemp("", "attachment-*"+suffix)
+	if err != nil {
+		return "", nil, fmt.Errorf("create temp file: %w", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return "", nil, fmt.Errorf("write temp file: %w", err)
+	}
+	f.Close()
+	return f.Name(), func() { os.Remove(f.Name()) }, nil
+}
@@ -0,0 +1,340 @@
+// post-service/service/attachment_service.go
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"post-service/models"
+	pb "post-service/protos/postpb"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	uploadURLTTL = 15 * time.Minute
+
+	// maxAttachmentUploadSize bounds UploadAttachment's in-memory buffer;
+	// larger media should go through the pre-signed CreateUploadURL flow
+	// instead, which streams straight to object storage.
+	maxAttachmentUploadSize = 50 << 20 // 50 MiB
+)
+
+// attachmentPostID returns an attachment's post ID as a proto uint32, or 0
+// if it isn't attached to a post yet.
+func attachmentPostID(a models.Attachment) uint32 {
+	if a.PostID == nil {
+		return 0
+	}
+	return uint32(*a.PostID)
+}
+
+// sanitizeFileName reduces a client-supplied file name to a bare base
+// name with NUL bytes stripped, so it can never smuggle a path-traversal
+// segment (e.g. "../../../../etc/cron.d/x") into a storage key built from
+// it - storage.Backend implementations join the key onto a trusted root
+// path without any sanitization of their own.
+func sanitizeFileName(name string) string {
+	name = strings.ReplaceAll(name, "\x00", "")
+	base := filepath.Base(filepath.FromSlash(name))
+	if base == "" || base == "." || base == ".." || base == string(filepath.Separator) {
+		return "attachment"
+	}
+	return base
+}
+
+// attachmentKindFromMime classifies a MIME type into the coarse kinds this
+// service tracks.
+func attachmentKindFromMime(mimeType string) models.AttachmentKind {
+	switch {
+	case strings.HasPrefix(mimeType, "image/"):
+		return models.AttachmentKindImage
+	case strings.HasPrefix(mimeType, "video/"):
+		return models.AttachmentKindVideo
+	default:
+		return models.AttachmentKindFile
+	}
+}
+
+func (s *PostServiceServer) attachmentToPb(a models.Attachment) *pb.Attachment {
+	return &pb.Attachment{
+		Id:             uint32(a.ID),
+		PostId:         attachmentPostID(a),
+		Kind:           string(a.Kind),
+		MimeType:       a.MimeType,
+		Size:           a.Size,
+		Width:          uint32(a.Width),
+		Height:         uint32(a.Height),
+		Url:            s.storage.PublicURL(a.StorageKey),
+		ChecksumSha256: a.ChecksumSHA256,
+		CreatedAt:      timestamppb.New(a.CreatedAt),
+	}
+}
+
+func (s *PostServiceServer) attachmentsToPb(attachments []models.Attachment) []*pb.Attachment {
+	result := make([]*pb.Attachment, len(attachments))
+	for i, a := range attachments {
+		result[i] = s.attachmentToPb(a)
+	}
+	return result
+}
+
+// CreateUploadURL hands the caller a pre-signed URL to upload attachment
+// bytes directly to object storage; the client then calls AttachMedia with
+// the same storage key once the upload succeeds.
+func (s *PostServiceServer) CreateUploadURL(ctx context.Context, req *pb.CreateUploadURLRequest) (*pb.CreateUploadURLResponse, error) {
+	storageKey := fmt.Sprintf("posts/%d/%d-%s", req.PostId, time.Now().UnixNano(), sanitizeFileName(req.FileName))
+
+	uploadURL, err := s.storage.PresignUpload(ctx, storageKey, req.MimeType, uploadURLTTL)
+	if err != nil {
+		return &pb.CreateUploadURLResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to create upload URL: %v", err),
+		}, nil
+	}
+
+	return &pb.CreateUploadURLResponse{
+		UploadUrl:  uploadURL,
+		StorageKey: storageKey,
+		ExpiresAt:  timestamppb.New(time.Now().Add(uploadURLTTL)),
+		Success:    true,
+	}, nil
+}
+
+// AttachMedia records an already-uploaded object as an attachment on a
+// post.
+func (s *PostServiceServer) AttachMedia(ctx context.Context, req *pb.AttachMediaRequest) (*pb.AttachMediaResponse, error) {
+	post, err := s.repo.GetByID(uint(req.PostId))
+	if err != nil {
+		return &pb.AttachMediaResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	attachment := &models.Attachment{
+		PostID:         &post.ID,
+		UserID:         post.UserID,
+		Kind:           models.AttachmentKind(req.Kind),
+		MimeType:       req.MimeType,
+		Size:           req.Size,
+		Width:          int(req.Width),
+		Height:         int(req.Height),
+		StorageKey:     req.StorageKey,
+		ChecksumSHA256: req.ChecksumSha256,
+	}
+
+	if err := s.attachmentRepo.Create(attachment); err != nil {
+		return &pb.AttachMediaResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to attach media: %v", err),
+		}, nil
+	}
+
+	return &pb.AttachMediaResponse{
+		Attachment: s.attachmentToPb(*attachment),
+		Success:    true,
+		Message:    "Media attached successfully",
+	}, nil
+}
+
+// DetachMedia removes an attachment from a post and schedules its storage
+// object for cleanup via the outbox, rather than deleting it inline, so a
+// failed storage delete can't leak the blob. Like DetachAttachment/Unlink,
+// the delete is scoped to req.UserId, so a caller can't delete (and purge
+// the storage object of) an attachment they don't own.
+func (s *PostServiceServer) DetachMedia(ctx context.Context, req *pb.DetachMediaRequest) (*pb.DetachMediaResponse, error) {
+	if _, err := s.attachmentRepo.DeleteWithOutbox(uint(req.AttachmentId), req.UserId, s.outboxRepo); err != nil {
+		return &pb.DetachMediaResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to detach media: %v", err),
+		}, nil
+	}
+
+	return &pb.DetachMediaResponse{
+		Success: true,
+		Message: "Media detached successfully",
+	}, nil
+}
+
+// ListPostMedia returns a post's attachments in display order.
+func (s *PostServiceServer) ListPostMedia(ctx context.Context, req *pb.ListPostMediaRequest) (*pb.ListPostMediaResponse, error) {
+	attachments, err := s.attachmentRepo.ListByPost(uint(req.PostId))
+	if err != nil {
+		return &pb.ListPostMediaResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to list media: %v", err),
+		}, nil
+	}
+
+	return &pb.ListPostMediaResponse{
+		Attachments: s.attachmentsToPb(attachments),
+		Success:     true,
+	}, nil
+}
+
+// UploadAttachment accepts chunked attachment bytes over a client stream,
+// probing image/video dimensions server-side instead of trusting
+// client-supplied values. Unlike CreateUploadURL/AttachMedia, the resulting
+// attachment isn't linked to a post yet (PostID is nil) — callers link it
+// afterwards with AttachAttachments, which lets a client upload media ahead
+// of finishing (or even creating) the post it belongs to.
+func (s *PostServiceServer) UploadAttachment(stream pb.PostService_UploadAttachmentServer) error {
+	var (
+		userID   string
+		fileName string
+		mimeType string
+		buf      bytes.Buffer
+	)
+
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if meta := chunk.GetMetadata(); meta != nil {
+			userID = meta.UserId
+			fileName = meta.FileName
+			mimeType = meta.MimeType
+			continue
+		}
+
+		data := chunk.GetChunk()
+		if buf.Len()+len(data) > maxAttachmentUploadSize {
+			return stream.SendAndClose(&pb.UploadAttachmentResponse{
+				Success: false,
+				Message: "attachment exceeds maximum upload size",
+			})
+		}
+		buf.Write(data)
+	}
+
+	if userID == "" {
+		return stream.SendAndClose(&pb.UploadAttachmentResponse{Success: false, Message: "user_id is required"})
+	}
+	if buf.Len() == 0 {
+		return stream.SendAndClose(&pb.UploadAttachmentResponse{Success: false, Message: "no attachment bytes received"})
+	}
+
+	data := buf.Bytes()
+	if mimeType == "" {
+		mimeType = http.DetectContentType(data)
+	}
+	kind := attachmentKindFromMime(mimeType)
+
+	var width, height int
+	switch kind {
+	case models.AttachmentKindImage:
+		// Best-effort: a malformed header shouldn't fail the whole upload.
+		width, height, _ = probeImageDimensions(data)
+	case models.AttachmentKindVideo:
+		if path, cleanup, err := writeTempVideo(data, filepath.Ext(fileName)); err == nil {
+			defer cleanup()
+			width, height, _ = s.videoProber.Probe(path)
+		}
+	}
+
+	checksum := sha256.Sum256(data)
+	storageKey := fmt.Sprintf("attachments/%s/%d-%s", userID, time.Now().UnixNano(), sanitizeFileName(fileName))
+
+	if err := s.storage.Put(stream.Context(), storageKey, bytes.NewReader(data), int64(len(data)), mimeType); err != nil {
+		return stream.SendAndClose(&pb.UploadAttachmentResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to store attachment: %v", err),
+		})
+	}
+
+	attachment := &models.Attachment{
+		UserID:         userID,
+		Kind:           kind,
+		MimeType:       mimeType,
+		Size:           int64(len(data)),
+		Width:          width,
+		Height:         height,
+		StorageKey:     storageKey,
+		ChecksumSHA256: hex.EncodeToString(checksum[:]),
+	}
+
+	if err := s.attachmentRepo.CreateWithOutbox(attachment, s.outboxRepo); err != nil {
+		return stream.SendAndClose(&pb.UploadAttachmentResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to save attachment: %v", err),
+		})
+	}
+
+	return stream.SendAndClose(&pb.UploadAttachmentResponse{
+		Attachment: s.attachmentToPb(*attachment),
+		Success:    true,
+		Message:    "Attachment uploaded successfully",
+	})
+}
+
+// AttachAttachments links previously-uploaded, not-yet-attached attachments
+// (see UploadAttachment) to a post.
+func (s *PostServiceServer) AttachAttachments(ctx context.Context, req *pb.AttachAttachmentsRequest) (*pb.AttachAttachmentsResponse, error) {
+	post, err := s.repo.GetByID(uint(req.PostId))
+	if err != nil {
+		return &pb.AttachAttachmentsResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	ids := make([]uint, len(req.AttachmentIds))
+	for i, id := range req.AttachmentIds {
+		ids[i] = uint(id)
+	}
+
+	linked, err := s.attachmentRepo.AttachToPost(ids, post.UserID, post.ID)
+	if err != nil {
+		return &pb.AttachAttachmentsResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to attach attachments: %v", err),
+		}, nil
+	}
+
+	attachments, err := s.attachmentRepo.ListByPost(post.ID)
+	if err != nil {
+		return &pb.AttachAttachmentsResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to reload post attachments: %v", err),
+		}, nil
+	}
+
+	return &pb.AttachAttachmentsResponse{
+		Attachments: s.attachmentsToPb(attachments),
+		Linked:      uint32(linked),
+		Success:     true,
+		Message:     "Attachments linked successfully",
+	}, nil
+}
+
+// DetachAttachment unlinks an attachment from its post without deleting it,
+// returning it to the caller's pool of reusable attachments. Compare
+// DetachMedia, which permanently deletes the attachment and its storage
+// object.
+func (s *PostServiceServer) DetachAttachment(ctx context.Context, req *pb.DetachAttachmentRequest) (*pb.DetachAttachmentResponse, error) {
+	if err := s.attachmentRepo.Unlink(uint(req.AttachmentId), req.UserId); err != nil {
+		return &pb.DetachAttachmentResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to detach attachment: %v", err),
+		}, nil
+	}
+
+	return &pb.DetachAttachmentResponse{
+		Success: true,
+		Message: "Attachment detached successfully",
+	}, nil
+}
@@ -0,0 +1,61 @@
+// post-service/service/event_handlers.go
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"post-service/repository"
+
+	eventpb "post-service/protos/eventpb"
+)
+
+// userDeletedPayload mirrors the EventData shape the event-service
+// publishes for "user.deleted".
+type userDeletedPayload struct {
+	ID string `json:"id"`
+}
+
+// HandleUserDeleted cascades a deleted user onto their posts via
+// DeletePostsByUserID, so a deleted user's content doesn't linger as an
+// orphaned read model. It's registered on an EventConsumer via
+// Consumer.On("user.deleted", ...).
+//
+// It deliberately doesn't go through the DeletePosts RPC path: that
+// resolves each userID through userClient.GetUser first, which fires
+// precisely for a user the user-service just deleted and can no longer
+// look up, and treats "matched zero posts" (the common case - most
+// deleted users never authored one) as a failure. Either would make this
+// handler return an error on essentially every user.deleted event, and
+// because EventConsumer only ever persists a contiguous ack cursor, one
+// permanently-failing event freezes that cursor forever.
+func (s *PostServiceServer) HandleUserDeleted(ctx context.Context, evt *eventpb.Event) error {
+	var payload userDeletedPayload
+	if err := json.Unmarshal([]byte(evt.EventData), &payload); err != nil {
+		return fmt.Errorf("unmarshal user.deleted payload: %w", err)
+	}
+	if payload.ID == "" {
+		return fmt.Errorf("user.deleted event %d is missing a user id", evt.Id)
+	}
+
+	deletedIDs, err := s.DeletePostsByUserID(ctx, payload.ID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNoPostsMatched) {
+			log.Printf("✅ User %s had no posts to cascade-delete", payload.ID)
+			return nil
+		}
+		return fmt.Errorf("cascade delete posts for user %s: %w", payload.ID, err)
+	}
+
+	for _, id := range deletedIDs {
+		if err := s.indexer.DeletePost(ctx, uint(id)); err != nil {
+			fmt.Printf("⚠️ Failed to remove post %d from search index: %v\n", id, err)
+		}
+	}
+
+	log.Printf("✅ Cascaded deletion of %d post(s) for deleted user %s", len(deletedIDs), payload.ID)
+	return nil
+}
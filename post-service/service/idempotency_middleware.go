@@ -0,0 +1,243 @@
+// post-service/service/idempotency_middleware.go
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"post-service/models"
+	pb "post-service/protos/postpb"
+	"post-service/repository"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// idempotencyKeyHeader is the gRPC metadata key clients set to make a
+// mutating call safely retryable.
+const idempotencyKeyHeader = "idempotency-key"
+
+// idempotencyTTL bounds how long a cached response is replayed before the
+// sweeper reclaims it; long enough to cover client retry windows, short
+// enough that the table doesn't grow unbounded.
+const idempotencyTTL = 24 * time.Hour
+
+// idempotentMethods lists the RPCs guarded by IdempotencyMiddleware, keyed
+// by their unqualified gRPC method name (the part after the last "/").
+// Read-only RPCs aren't listed: replaying a cached GetPost response would
+// just serve stale data for no safety benefit.
+var idempotentMethods = map[string]bool{
+	"CreatePost":     true,
+	"UpdatePost":     true,
+	"PatchPost":      true,
+	"DeletePost":     true,
+	"DeletePosts":    true,
+	"IncrementVisit": true,
+}
+
+// IdempotencyMiddleware returns a unary interceptor that short-circuits
+// retried mutating calls: the first call with a given Idempotency-Key runs
+// normally and its response is cached; a retry with the same key, user and
+// request payload replays the cached response instead of re-executing the
+// mutation, and a retry with the same key but a different payload is
+// rejected with AlreadyExists instead of silently doing the wrong thing.
+func IdempotencyMiddleware(repo repository.IdempotencyRepository) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		method := methodName(info.FullMethod)
+		if !idempotentMethods[method] {
+			return handler(ctx, req)
+		}
+
+		key := idempotencyKeyFromContext(ctx)
+		if key == "" {
+			// Idempotency is opt-in: a caller that doesn't send a key gets
+			// the old at-most-once-per-RPC-call behavior.
+			return handler(ctx, req)
+		}
+
+		msg, ok := req.(proto.Message)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		userID := userIDFromRequest(req)
+		hash, err := hashRequest(msg)
+		if err != nil {
+			return handler(ctx, req)
+		}
+
+		existing, err := repo.Find(key, userID, method)
+		if err != nil {
+			return handler(ctx, req)
+		}
+
+		if existing != nil {
+			if existing.RequestHash != hash {
+				return nil, status.Errorf(codes.AlreadyExists, "idempotency key %q was already used with a different request", key)
+			}
+			if cached, err := decodeCachedResponse(method, existing.ResponseData); err == nil {
+				return cached, nil
+			}
+			// Cached payload didn't decode (e.g. response shape changed
+			// since it was stored) — fall through and re-run the call.
+		}
+
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, err
+		}
+
+		respMsg, ok := resp.(proto.Message)
+		if !ok {
+			return resp, nil
+		}
+
+		// Only a genuine success is safe to cache: this codebase signals
+		// business failures as a (Success: false, Message: ...) response
+		// with a nil Go error (see post_service.go), so a transient
+		// failure would otherwise be marshaled and replayed as the
+		// permanent outcome of this key for the next idempotencyTTL.
+		if !responseSucceeded(respMsg) {
+			return resp, nil
+		}
+
+		data, marshalErr := protojson.Marshal(respMsg)
+		if marshalErr != nil {
+			return resp, nil
+		}
+
+		now := time.Now().UTC()
+		saveErr := repo.Save(&models.IdempotencyKey{
+			Key:          key,
+			UserID:       userID,
+			Method:       method,
+			RequestHash:  hash,
+			ResponseData: string(data),
+			StatusCode:   uint32(codes.OK),
+			CreatedAt:    now,
+			ExpiresAt:    now.Add(idempotencyTTL),
+		})
+		if saveErr == nil {
+			return resp, nil
+		}
+
+		if !isDuplicateKeyError(saveErr) {
+			log.Printf("⚠️ Idempotency: failed to cache response for key %q method %s: %v", key, method, saveErr)
+			return resp, nil
+		}
+
+		// Another concurrent retry won the race and saved its response
+		// first (both passed the Find check before either Save'd). Rather
+		// than silently accepting whichever duplicate mutation happened
+		// to finish last, return the response that's now authoritative
+		// for this key so every caller sees the same outcome.
+		if winner, findErr := repo.Find(key, userID, method); findErr == nil && winner != nil {
+			if cached, decodeErr := decodeCachedResponse(method, winner.ResponseData); decodeErr == nil {
+				return cached, nil
+			}
+		}
+		return resp, nil
+	}
+}
+
+// responseSucceeded reports whether resp represents a genuine success, for
+// the response types IdempotencyMiddleware is allowed to cache.
+func responseSucceeded(resp proto.Message) bool {
+	switch r := resp.(type) {
+	case *pb.PostResponse:
+		return r.Success
+	case *pb.DeletePostResponse:
+		return r.Success
+	default:
+		return false
+	}
+}
+
+// isDuplicateKeyError reports whether err looks like a unique-constraint
+// violation on (key, user_id, method), i.e. a concurrent retry already
+// cached a response for this same call.
+func isDuplicateKeyError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "duplicate") || strings.Contains(msg, "unique constraint") || strings.Contains(msg, "unique_violation")
+}
+
+func idempotencyKeyFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(idempotencyKeyHeader)
+	if len(values) == 0 {
+		return ""
+	}
+	return strings.TrimSpace(values[0])
+}
+
+// methodName strips a gRPC FullMethod ("/post.PostService/CreatePost") down
+// to just "CreatePost".
+func methodName(fullMethod string) string {
+	idx := strings.LastIndex(fullMethod, "/")
+	if idx == -1 {
+		return fullMethod
+	}
+	return fullMethod[idx+1:]
+}
+
+// userIDFromRequest extracts the acting user ID from a request message, so
+// the same key reused by two different users is never treated as a replay
+// of the same call.
+func userIDFromRequest(req interface{}) string {
+	switch r := req.(type) {
+	case *pb.CreatePostRequest:
+		return r.UserId
+	case *pb.UpdatePostRequest:
+		return r.UserId
+	case *pb.PatchPostRequest:
+		return r.UserId
+	case *pb.DeletePostRequest:
+		return r.UserId
+	case *pb.DeletePostsRequest:
+		return strings.Join(r.UserIds, ",")
+	case *pb.IncrementVisitRequest:
+		return ""
+	default:
+		return ""
+	}
+}
+
+func hashRequest(msg proto.Message) (string, error) {
+	data, err := protojson.Marshal(msg)
+	if err != nil {
+		return "", fmt.Errorf("marshal request for hashing: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// decodeCachedResponse rebuilds the proto response for method from its
+// stored JSON so a replayed call returns the exact same payload.
+func decodeCachedResponse(method, data string) (proto.Message, error) {
+	var msg proto.Message
+	switch method {
+	case "CreatePost", "UpdatePost", "PatchPost", "IncrementVisit":
+		msg = &pb.PostResponse{}
+	case "DeletePost", "DeletePosts":
+		msg = &pb.DeletePostResponse{}
+	default:
+		return nil, fmt.Errorf("no cached response type registered for method %q", method)
+	}
+
+	if err := protojson.Unmarshal([]byte(data), msg); err != nil {
+		return nil, fmt.Errorf("unmarshal cached response for %q: %w", method, err)
+	}
+	return msg, nil
+}
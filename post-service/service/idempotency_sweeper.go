@@ -0,0 +1,70 @@
+// post-service/service/idempotency_sweeper.go
+package service
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"post-service/repository"
+)
+
+const idempotencySweepInterval = 10 * time.Minute
+
+// IdempotencySweeper periodically deletes expired idempotency_keys rows so
+// the table doesn't grow without bound, independently of the request path.
+type IdempotencySweeper struct {
+	repo repository.IdempotencyRepository
+
+	stopCh chan struct{}
+	doneCh chan struct{}
+	once   sync.Once
+}
+
+func NewIdempotencySweeper(repo repository.IdempotencyRepository) *IdempotencySweeper {
+	return &IdempotencySweeper{
+		repo:   repo,
+		stopCh: make(chan struct{}),
+		doneCh: make(chan struct{}),
+	}
+}
+
+// Start runs the sweep loop in a background goroutine.
+func (s *IdempotencySweeper) Start() {
+	go s.run()
+}
+
+// Stop signals the sweep loop to exit and waits for the in-flight sweep to
+// finish, so callers can coordinate it with the rest of graceful shutdown.
+func (s *IdempotencySweeper) Stop() {
+	s.once.Do(func() { close(s.stopCh) })
+	<-s.doneCh
+}
+
+func (s *IdempotencySweeper) run() {
+	defer close(s.doneCh)
+
+	ticker := time.NewTicker(idempotencySweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopCh:
+			log.Println("🛑 Idempotency sweeper stopped")
+			return
+		case <-ticker.C:
+			s.sweep()
+		}
+	}
+}
+
+func (s *IdempotencySweeper) sweep() {
+	count, err := s.repo.DeleteExpired(time.Now().UTC())
+	if err != nil {
+		log.Printf("⚠️ Idempotency sweeper: failed to delete expired keys: %v", err)
+		return
+	}
+	if count > 0 {
+		log.Printf("📊 Idempotency sweeper: removed %d expired key(s)", count)
+	}
+}
@@ -0,0 +1,241 @@
+// post-service/service/outbox_dispatcher.go
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"post-service/federation"
+	"post-service/models"
+	eventpb "post-service/protos/eventpb"
+	"post-service/repository"
+	"post-service/storage"
+
+	event_client "post-service/clients/event_client"
+)
+
+// attachmentCleanupPayload mirrors the EventData shape written by
+// repository.attachmentCleanupEvent.
+type attachmentCleanupPayload struct {
+	ID         uint   `json:"id"`
+	StorageKey string `json:"storageKey"`
+}
+
+// federationNotePayload mirrors the EventData shape written by
+// repository.federationOutboxEvent.
+type federationNotePayload struct {
+	Slug      string `json:"slug"`
+	Title     string `json:"title"`
+	Content   string `json:"content"`
+	UserID    string `json:"userId"`
+	CreatedAt string `json:"createdAt"`
+}
+
+const (
+	outboxPollInterval = 2 * time.Second
+	outboxBatchSize    = 50
+	outboxMaxBackoff   = time.Minute
+)
+
+// OutboxDispatcher polls the transactional outbox for unpublished domain
+// events and forwards them to the event-service, marking rows published on
+// success and backing off exponentially on failure so a flapping
+// event-service doesn't turn into a publish-retry storm.
+type OutboxDispatcher struct {
+	outboxRepo  repository.OutboxRepository
+	eventClient *event_client.EventServiceClient
+	storage     storage.Backend
+	federation  *federation.Service
+
+	stopCh chan struct{}
+	doneCh chan struct{}
+	once   sync.Once
+}
+
+func NewOutboxDispatcher(outboxRepo repository.OutboxRepository, eventClient *event_client.EventServiceClient, storageBackend storage.Backend, federationService *federation.Service) *OutboxDispatcher {
+	return &OutboxDispatcher{
+		outboxRepo:  outboxRepo,
+		eventClient: eventClient,
+		storage:     storageBackend,
+		federation:  federationService,
+		stopCh:      make(chan struct{}),
+		doneCh:      make(chan struct{}),
+	}
+}
+
+// Start runs the dispatch loop in a background goroutine.
+func (d *OutboxDispatcher) Start() {
+	go d.run()
+}
+
+// Stop signals the dispatch loop to exit and waits for it to finish the
+// in-flight batch, so callers can coordinate it with the rest of graceful
+// shutdown.
+func (d *OutboxDispatcher) Stop() {
+	d.once.Do(func() { close(d.stopCh) })
+	<-d.doneCh
+}
+
+func (d *OutboxDispatcher) run() {
+	defer close(d.doneCh)
+
+	ticker := time.NewTicker(outboxPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-d.stopCh:
+			log.Println("🛑 Outbox dispatcher stopped")
+			return
+		case <-ticker.C:
+			d.dispatchBatch()
+		}
+	}
+}
+
+func (d *OutboxDispatcher) dispatchBatch() {
+	events, err := d.outboxRepo.FetchUnpublished(outboxBatchSize)
+	if err != nil {
+		log.Printf("⚠️ Outbox: failed to fetch unpublished events: %v", err)
+		return
+	}
+
+	for _, evt := range events {
+		d.dispatchOne(evt)
+	}
+
+	if lag, err := d.outboxRepo.OldestUnpublishedAge(); err == nil {
+		outboxLagSeconds.Set(lag.Seconds())
+		if lag > 0 {
+			log.Printf("📊 Outbox lag: oldest unpublished event is %s old", lag.Round(time.Second))
+		}
+	}
+}
+
+func (d *OutboxDispatcher) dispatchOne(evt models.OutboxEvent) {
+	if evt.Attempts > 0 && time.Since(evt.UpdatedAt) < outboxBackoff(evt.Attempts) {
+		// FetchUnpublished already claimed this row before dispatchBatch
+		// ever saw it. Release the claim now rather than leaving it held
+		// until outboxClaimTTL expires - otherwise this row would become
+		// invisible to the next FetchUnpublished call for up to a minute,
+		// turning every retry after the first failure into a wait far
+		// longer than the intended exponential backoff.
+		if err := d.outboxRepo.ReleaseClaim(evt.ID); err != nil {
+			log.Printf("⚠️ Outbox: failed to release claim on event %d pending backoff: %v", evt.ID, err)
+		}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if evt.EventType == "attachment.cleanup" {
+		d.cleanupAttachment(ctx, evt)
+		return
+	}
+
+	if evt.EventType == "post.federate" {
+		d.federatePost(ctx, evt)
+		return
+	}
+
+	_, err := d.eventClient.PublishEvent(ctx, &eventpb.PublishEventRequest{
+		AggregateId:   evt.AggregateID,
+		AggregateType: evt.AggregateType,
+		EventType:     evt.EventType,
+		EventData:     evt.EventData,
+		Metadata:      evt.Metadata,
+	})
+	if err != nil {
+		log.Printf("⚠️ Outbox: failed to publish event %d (%s), attempt %d: %v", evt.ID, evt.EventType, evt.Attempts+1, err)
+		d.markFailed(evt.ID, err)
+		return
+	}
+
+	if err := d.outboxRepo.MarkPublished(evt.ID); err != nil {
+		log.Printf("⚠️ Outbox: failed to mark event %d published: %v", evt.ID, err)
+	}
+}
+
+// markFailed records a failed publish attempt against both the outbox row
+// (for backoff/inspection) and the outboxPublishFailuresTotal counter (for
+// alerting).
+func (d *OutboxDispatcher) markFailed(id uint, cause error) {
+	outboxPublishFailuresTotal.Inc()
+	if markErr := d.outboxRepo.MarkFailed(id, cause.Error()); markErr != nil {
+		log.Printf("⚠️ Outbox: failed to record failure for event %d: %v", id, markErr)
+	}
+}
+
+// cleanupAttachment deletes the storage object for a deleted attachment.
+// Routing this through the outbox (instead of deleting inline during the
+// post/attachment delete transaction) means a storage outage just delays
+// cleanup instead of leaking the blob.
+func (d *OutboxDispatcher) cleanupAttachment(ctx context.Context, evt models.OutboxEvent) {
+	var payload attachmentCleanupPayload
+	if err := json.Unmarshal([]byte(evt.EventData), &payload); err != nil {
+		log.Printf("⚠️ Outbox: malformed attachment cleanup payload for event %d: %v", evt.ID, err)
+		d.markFailed(evt.ID, err)
+		return
+	}
+
+	if err := d.storage.Delete(ctx, payload.StorageKey); err != nil {
+		log.Printf("⚠️ Outbox: failed to delete attachment object %s, attempt %d: %v", payload.StorageKey, evt.Attempts+1, err)
+		d.markFailed(evt.ID, err)
+		return
+	}
+
+	if err := d.outboxRepo.MarkPublished(evt.ID); err != nil {
+		log.Printf("⚠️ Outbox: failed to mark attachment cleanup %d done: %v", evt.ID, err)
+	}
+}
+
+// federatePost delivers the post as a signed ActivityPub Create(Note)
+// activity to the author's followers. Routing this through the outbox
+// means a federation outage just delays delivery instead of the post
+// silently never reaching followers.
+func (d *OutboxDispatcher) federatePost(ctx context.Context, evt models.OutboxEvent) {
+	var payload federationNotePayload
+	if err := json.Unmarshal([]byte(evt.EventData), &payload); err != nil {
+		log.Printf("⚠️ Outbox: malformed federation payload for event %d: %v", evt.ID, err)
+		d.markFailed(evt.ID, err)
+		return
+	}
+
+	createdAt, err := time.Parse(time.RFC3339, payload.CreatedAt)
+	if err != nil {
+		createdAt = time.Now().UTC()
+	}
+
+	if err := d.federation.PublishNote(ctx, federation.NoteInput{
+		Slug:      payload.Slug,
+		Title:     payload.Title,
+		Content:   payload.Content,
+		UserID:    payload.UserID,
+		CreatedAt: createdAt,
+	}); err != nil {
+		log.Printf("⚠️ Outbox: failed to federate post %s, attempt %d: %v", evt.AggregateID, evt.Attempts+1, err)
+		d.markFailed(evt.ID, err)
+		return
+	}
+
+	if err := d.outboxRepo.MarkPublished(evt.ID); err != nil {
+		log.Printf("⚠️ Outbox: failed to mark federation event %d published: %v", evt.ID, err)
+	}
+}
+
+// outboxBackoff returns an exponential backoff capped at outboxMaxBackoff,
+// doubling per failed attempt.
+func outboxBackoff(attempts uint) time.Duration {
+	if attempts > 10 {
+		attempts = 10
+	}
+	backoff := time.Second << attempts
+	if backoff > outboxMaxBackoff {
+		return outboxMaxBackoff
+	}
+	return backoff
+}
@@ -0,0 +1,24 @@
+// post-service/service/outbox_metrics.go
+package service
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Outbox metrics are registered against the default registry so they show
+// up alongside Go's built-in process/runtime collectors at /metrics.
+var (
+	outboxLagSeconds = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "post_service_outbox_lag_seconds",
+		Help: "Age in seconds of the oldest unpublished outbox event.",
+	})
+
+	outboxPublishFailuresTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "post_service_outbox_publish_failures_total",
+		Help: "Total number of failed outbox publish attempts across all event types.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(outboxLagSeconds, outboxPublishFailuresTotal)
+}
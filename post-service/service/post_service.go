@@ -6,10 +6,12 @@ import (
 	"strings"
 	"time"
 
+	"post-service/events"
 	"post-service/models"
-	eventpb "post-service/protos/eventpb"
 	pb "post-service/protos/postpb"
 	"post-service/repository"
+	"post-service/search"
+	"post-service/storage"
 
 	event_client "post-service/clients/event_client"
 	user_client "post-service/clients/user_client"
@@ -19,13 +21,35 @@ import (
 
 type PostServiceServer struct {
 	pb.UnimplementedPostServiceServer
-	repo        repository.PostRepository
-	eventClient *event_client.EventServiceClient
-	userClient  *user_client.UserServiceClient
+	repo           repository.PostRepository
+	outboxRepo     repository.OutboxRepository
+	eventClient    *event_client.EventServiceClient
+	userClient     *user_client.UserServiceClient
+	indexer        search.Indexer
+	attachmentRepo repository.AttachmentRepository
+	storage        storage.Backend
+	videoProber    VideoProber
 }
 
-func NewPostServiceServer(repo repository.PostRepository, eventClient *event_client.EventServiceClient, userClient *user_client.UserServiceClient) *PostServiceServer {
-	return &PostServiceServer{repo: repo, eventClient: eventClient, userClient: userClient}
+func NewPostServiceServer(
+	repo repository.PostRepository,
+	outboxRepo repository.OutboxRepository,
+	eventClient *event_client.EventServiceClient,
+	userClient *user_client.UserServiceClient,
+	indexer search.Indexer,
+	attachmentRepo repository.AttachmentRepository,
+	storageBackend storage.Backend,
+) *PostServiceServer {
+	return &PostServiceServer{
+		repo:           repo,
+		outboxRepo:     outboxRepo,
+		eventClient:    eventClient,
+		userClient:     userClient,
+		indexer:        indexer,
+		attachmentRepo: attachmentRepo,
+		storage:        storageBackend,
+		videoProber:    FFProbeVideoProber{},
+	}
 }
 
 func (s *PostServiceServer) CreatePost(ctx context.Context, req *pb.CreatePostRequest) (*pb.PostResponse, error) {
@@ -71,26 +95,32 @@ func (s *PostServiceServer) CreatePost(ctx context.Context, req *pb.CreatePostRe
 		IsFeatured: req.IsFeatured,
 	}
 
-	err = s.repo.Create(post)
+	// 📤 The creation event is written to the outbox in the same
+	// transaction as the post row, so it survives a crash between the DB
+	// commit and the publish RPC. The OutboxDispatcher delivers it.
+	event, err := s.buildOutboxEvent(ctx, func(ids events.CorrelationIDs) (*events.Envelope, error) {
+		return events.NewPostCreated(post, ids)
+	})
 	if err != nil {
 		return &pb.PostResponse{
 			Success: false,
-			Message: fmt.Sprintf("Failed to create post: %v", err),
+			Message: fmt.Sprintf("Failed to build post.created event: %v", err),
 		}, nil
 	}
 
-	// 📤 Publish domain event
-	_, err = s.eventClient.PublishEvent(ctx, &eventpb.PublishEventRequest{
-		AggregateId:   fmt.Sprintf("%d", post.ID),
-		AggregateType: "Post",
-		EventType:     "post.created",
-		EventData:     fmt.Sprintf(`{"title":"%s","userId":"%s"}`, post.Title, post.UserID),
-		Metadata:      fmt.Sprintf(`{"user_id":"%s","created_at":"%s"}`, req.UserId, time.Now().UTC().Format(time.RFC3339)),
-	})
-
+	err = s.repo.CreateWithOutbox(post, event)
 	if err != nil {
-		// Log but don't fail post creation
-		fmt.Printf("⚠️ Failed to publish event: %v\n", err)
+		return &pb.PostResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to create post: %v", err),
+		}, nil
+	}
+
+	// 🔎 Keep the search index in sync. For the Postgres backend this is a
+	// no-op (the search_vector column is already current); for an external
+	// backend it pushes the new document.
+	if err := s.indexer.IndexPost(ctx, post); err != nil {
+		fmt.Printf("⚠️ Failed to index post %d: %v\n", post.ID, err)
 	}
 
 	return &pb.PostResponse{
@@ -184,26 +214,26 @@ func (s *PostServiceServer) UpdatePost(ctx context.Context, req *pb.UpdatePostRe
 	existingPost.Content = req.Content
 	existingPost.IsFeatured = req.IsFeatured
 
-	err = s.repo.Update(existingPost)
+	event, err := s.buildOutboxEvent(ctx, func(ids events.CorrelationIDs) (*events.Envelope, error) {
+		return events.NewPostUpdated(existingPost, ids)
+	})
 	if err != nil {
 		return &pb.PostResponse{
 			Success: false,
-			Message: fmt.Sprintf("Failed to update post: %v", err),
+			Message: fmt.Sprintf("Failed to build post.updated event: %v", err),
 		}, nil
 	}
 
-	// 📤 Publish domain event
-	_, err = s.eventClient.PublishEvent(ctx, &eventpb.PublishEventRequest{
-		AggregateId:   fmt.Sprintf("%d", existingPost.ID),
-		AggregateType: "Post",
-		EventType:     "post.updated",
-		EventData:     fmt.Sprintf(`{"title":"%s","userId":"%s"}`, existingPost.Title, existingPost.UserID),
-		Metadata:      fmt.Sprintf(`{"user_id":"%s","updated_at":"%s"}`, req.UserId, time.Now().UTC().Format(time.RFC3339)),
-	})
-
+	err = s.repo.UpdateWithOutbox(existingPost, event)
 	if err != nil {
-		// Log but don't fail post update
-		fmt.Printf("⚠️ Failed to publish event: %v\n", err)
+		return &pb.PostResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to update post: %v", err),
+		}, nil
+	}
+
+	if err := s.indexer.IndexPost(ctx, existingPost); err != nil {
+		fmt.Printf("⚠️ Failed to reindex post %d: %v\n", existingPost.ID, err)
 	}
 
 	return &pb.PostResponse{
@@ -316,8 +346,21 @@ func (s *PostServiceServer) PatchPost(ctx context.Context, req *pb.PatchPostRequ
 		}, nil
 	}
 
+	// 📤 Publish domain event with detailed change information, via the
+	// outbox so it commits atomically with the patch.
+	changes := s.buildFieldChanges(updatedFields, oldValues, existingPost)
+	event, err := s.buildOutboxEvent(ctx, func(ids events.CorrelationIDs) (*events.Envelope, error) {
+		return events.NewPostPatched(existingPost, updatedFields, changes, ids)
+	})
+	if err != nil {
+		return &pb.PostResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to build post.patched event: %v", err),
+		}, nil
+	}
+
 	// Update the post in database
-	err = s.repo.Update(existingPost)
+	err = s.repo.UpdateWithOutbox(existingPost, event)
 	if err != nil {
 		return &pb.PostResponse{
 			Success: false,
@@ -325,32 +368,8 @@ func (s *PostServiceServer) PatchPost(ctx context.Context, req *pb.PatchPostRequ
 		}, nil
 	}
 
-	// 📤 Publish domain event with detailed change information
-	eventData := fmt.Sprintf(`{
-        "id": "%d",
-        "title": "%s",
-        "userId": "%s",
-        "updatedFields": ["%s"],
-        "changes": %s
-    }`,
-		existingPost.ID,
-		existingPost.Title,
-		existingPost.UserID,
-		strings.Join(updatedFields, `","`),
-		s.buildChangesJSON(updatedFields, oldValues, existingPost),
-	)
-
-	_, err = s.eventClient.PublishEvent(ctx, &eventpb.PublishEventRequest{
-		AggregateId:   fmt.Sprintf("%d", existingPost.ID),
-		AggregateType: "Post",
-		EventType:     "post.patched",
-		EventData:     eventData,
-		Metadata:      fmt.Sprintf(`{"user_id":"%s","updated_at":"%s","fields_count":%d}`, req.UserId, time.Now().UTC().Format(time.RFC3339), len(updatedFields)),
-	})
-
-	if err != nil {
-		// Log but don't fail post update
-		fmt.Printf("⚠️ Failed to publish patch event: %v\n", err)
+	if err := s.indexer.IndexPost(ctx, existingPost); err != nil {
+		fmt.Printf("⚠️ Failed to reindex post %d: %v\n", existingPost.ID, err)
 	}
 
 	return &pb.PostResponse{
@@ -369,26 +388,26 @@ func (s *PostServiceServer) DeletePost(ctx context.Context, req *pb.DeletePostRe
 		}, nil
 	}
 
-	err = s.repo.Delete(uint(req.Id), user.GetId())
+	event, err := s.buildOutboxEvent(ctx, func(ids events.CorrelationIDs) (*events.Envelope, error) {
+		return events.NewPostDeleted(uint(req.Id), req.UserId, ids)
+	})
 	if err != nil {
 		return &pb.DeletePostResponse{
 			Success: false,
-			Message: err.Error(),
+			Message: fmt.Sprintf("Failed to build post.deleted event: %v", err),
 		}, nil
 	}
 
-	// 📤 Publish domain event
-	_, err = s.eventClient.PublishEvent(ctx, &eventpb.PublishEventRequest{
-		AggregateId:   fmt.Sprintf("%d", req.Id),
-		AggregateType: "Post",
-		EventType:     "post.deleted",
-		EventData:     fmt.Sprintf(`{"id":%d,"userId":"%s"}`, req.Id, req.UserId),
-		Metadata:      fmt.Sprintf(`{"user_id":"%s","deleted_at":"%s"}`, req.UserId, time.Now().UTC().Format(time.RFC3339)),
-	})
-
+	err = s.repo.DeleteWithOutbox(uint(req.Id), user.GetId(), event)
 	if err != nil {
-		// Log but don't fail post deletion
-		fmt.Printf("⚠️ Failed to publish event: %v\n", err)
+		return &pb.DeletePostResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	if err := s.indexer.DeletePost(ctx, uint(req.Id)); err != nil {
+		fmt.Printf("⚠️ Failed to remove post %d from search index: %v\n", req.Id, err)
 	}
 
 	return &pb.DeletePostResponse{
@@ -573,7 +592,7 @@ func (s *PostServiceServer) GetPostsByUser(ctx context.Context, req *pb.GetPosts
 	}, nil
 }
 
-func (s *PostServiceServer) SearchPosts(ctx context.Context, req *pb.SearchPostsRequest) (*pb.ListPostsResponse, error) {
+func (s *PostServiceServer) SearchPosts(ctx context.Context, req *pb.SearchPostsRequest) (*pb.SearchPostsResponse, error) {
 	page := int(req.Page)
 	limit := int(req.Limit)
 
@@ -594,31 +613,70 @@ func (s *PostServiceServer) SearchPosts(ctx context.Context, req *pb.SearchPosts
 	slug := safeStringDeref(req.Slug)
 	author := safeStringDeref(req.Author)
 
-	fmt.Println("Searching posts with the following parameters:")
-	fmt.Printf("Query: %s\n", query)
-	fmt.Printf("Category: %s\n", category)
-	fmt.Printf("Title: %s\n", title)
-	fmt.Printf("Slug: %s\n", slug)
-	fmt.Printf("Author: %s\n", author)
-	fmt.Printf("Sort By: %s\n", req.SortBy)
-	fmt.Printf("Sort Order: %s\n", req.SortOrder)
-	fmt.Printf("Page: %d\n", page)
-	fmt.Printf("Limit: %d\n", limit)
+	// A free-text query is ranked by the search.Indexer (Postgres FTS or an
+	// external backend, depending on config); structured filters with no
+	// free text keep using the repository's direct SQL filtering.
+	if query != "" {
+		result, err := s.indexer.Search(ctx, search.Query{
+			Text:      query,
+			Category:  category,
+			Language:  req.Language,
+			MinScore:  float64(req.MinScore),
+			Highlight: req.Highlight,
+			Page:      page,
+			Limit:     limit,
+		})
+		if err != nil {
+			return &pb.SearchPostsResponse{
+				Success: false,
+				Message: fmt.Sprintf("Search failed: %v", err),
+			}, nil
+		}
+
+		results := make([]*pb.SearchResult, 0, len(result.Hits))
+		for _, hit := range result.Hits {
+			post, err := s.repo.GetByID(hit.PostID)
+			if err != nil {
+				continue
+			}
+
+			sr := &pb.SearchResult{
+				Post:  s.modelToProto(post),
+				Score: hit.Score,
+			}
+			if req.Highlight {
+				sr.Highlight = &pb.SearchHighlight{
+					Title:   hit.Highlight.Title,
+					Desc:    hit.Highlight.Desc,
+					Content: hit.Highlight.Content,
+				}
+			}
+			results = append(results, sr)
+		}
+
+		return &pb.SearchPostsResponse{
+			Results: results,
+			Total:   uint32(result.Total),
+			Page:    uint32(page),
+			Limit:   uint32(limit),
+			Success: true,
+		}, nil
+	}
 
 	posts, total, err := s.repo.SearchPosts(query, category, title, slug, author, req.SortBy, req.SortOrder, page, limit)
 	if err != nil {
-		return &pb.ListPostsResponse{
+		return &pb.SearchPostsResponse{
 			Success: false,
 		}, nil
 	}
 
-	protoPosts := make([]*pb.Post, len(posts))
+	results := make([]*pb.SearchResult, len(posts))
 	for i, post := range posts {
-		protoPosts[i] = s.modelToProto(&post)
+		results[i] = &pb.SearchResult{Post: s.modelToProto(&post)}
 	}
 
-	return &pb.ListPostsResponse{
-		Posts:   protoPosts,
+	return &pb.SearchPostsResponse{
+		Results: results,
 		Total:   uint32(total),
 		Page:    uint32(page),
 		Limit:   uint32(limit),
@@ -626,6 +684,51 @@ func (s *PostServiceServer) SearchPosts(ctx context.Context, req *pb.SearchPosts
 	}, nil
 }
 
+// ReindexAll rebuilds the search index for every post, streaming progress
+// back to the caller so an admin tool can show a live progress bar. For the
+// Postgres backend IndexPost is a no-op (the search_vector column is
+// generated), so this only walks the posts and reports progress; for an
+// external backend it repopulates the index from scratch.
+func (s *PostServiceServer) ReindexAll(req *pb.ReindexAllRequest, stream pb.PostService_ReindexAllServer) error {
+	const batchSize = 200
+
+	_, total, err := s.repo.List(1, 1, "", "")
+	if err != nil {
+		return fmt.Errorf("failed to count posts: %w", err)
+	}
+
+	var processed uint32
+	page := 1
+	for {
+		posts, _, err := s.repo.List(page, batchSize, "", "")
+		if err != nil {
+			return fmt.Errorf("failed to list posts for reindex: %w", err)
+		}
+		if len(posts) == 0 {
+			break
+		}
+
+		for i := range posts {
+			if err := s.indexer.IndexPost(stream.Context(), &posts[i]); err != nil {
+				fmt.Printf("⚠️ Failed to reindex post %d: %v\n", posts[i].ID, err)
+				continue
+			}
+			processed++
+		}
+
+		if err := stream.Send(&pb.ReindexAllResponse{
+			Processed: processed,
+			Total:     uint32(total),
+		}); err != nil {
+			return err
+		}
+
+		page++
+	}
+
+	return nil
+}
+
 func (s *PostServiceServer) CountPosts(ctx context.Context, req *pb.CountPostsRequest) (*pb.CountPostsResponse, error) {
 	count, err := s.repo.CountPosts(req.UserId, req.Category, req.IsFeatured)
 	if err != nil {
@@ -641,6 +744,25 @@ func (s *PostServiceServer) CountPosts(ctx context.Context, req *pb.CountPostsRe
 	}, nil
 }
 
+// Replay requeues every outbox event from req.FromId onward so the
+// dispatcher re-publishes them, letting an operator rebuild a downstream
+// read model that has drifted out of sync without replaying the whole
+// table.
+func (s *PostServiceServer) Replay(ctx context.Context, req *pb.ReplayRequest) (*pb.ReplayResponse, error) {
+	count, err := s.outboxRepo.Replay(uint(req.FromId))
+	if err != nil {
+		return &pb.ReplayResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to replay events: %v", err),
+		}, nil
+	}
+
+	return &pb.ReplayResponse{
+		Success:          true,
+		RepublishedCount: uint32(count),
+	}, nil
+}
+
 func (s *PostServiceServer) DeletePosts(ctx context.Context, req *pb.DeletePostsRequest) (*pb.DeletePostResponse, error) {
 	var mongoUserIds []string
 	if len(req.UserIds) > 0 {
@@ -657,7 +779,13 @@ func (s *PostServiceServer) DeletePosts(ctx context.Context, req *pb.DeletePosts
 		}
 	}
 
-	err := s.repo.DeletePosts(req.Ids, mongoUserIds)
+	// 📤 One outbox row per deleted post, written in the same transaction
+	// as the bulk delete. All rows share one CorrelationIDs so a consumer
+	// can tell they came from the same bulk-delete request. buildEvent runs
+	// inside the repository's transaction, once per post it actually
+	// matched and deleted - req.Ids alone isn't enough here, since a
+	// userIds-only call deletes posts this request never enumerated.
+	deletedIDs, err := s.repo.DeletePostsWithOutbox(req.Ids, mongoUserIds, postDeletedEventBuilder(ctx))
 	if err != nil {
 		return &pb.DeletePostResponse{
 			Success: false,
@@ -665,18 +793,9 @@ func (s *PostServiceServer) DeletePosts(ctx context.Context, req *pb.DeletePosts
 		}, nil
 	}
 
-	// 📤 Publish domain event for each deleted post
-	for _, id := range req.Ids {
-		_, err = s.eventClient.PublishEvent(ctx, &eventpb.PublishEventRequest{
-			AggregateId:   fmt.Sprintf("%d", id),
-			AggregateType: "Post",
-			EventType:     "post.deleted",
-			EventData:     fmt.Sprintf(`{"id":%d,"userId":"%s"}`, id, req.UserIds),
-			Metadata:      fmt.Sprintf(`{"user_id":"%s","deleted_at":"%s"}`, req.UserIds, time.Now().UTC().Format(time.RFC3339)),
-		})
-
-		if err != nil {
-			fmt.Printf("⚠️ Failed to publish delete event for post %d: %v\n", id, err)
+	for _, id := range deletedIDs {
+		if err := s.indexer.DeletePost(ctx, uint(id)); err != nil {
+			fmt.Printf("⚠️ Failed to remove post %d from search index: %v\n", id, err)
 		}
 	}
 
@@ -686,8 +805,46 @@ func (s *PostServiceServer) DeletePosts(ctx context.Context, req *pb.DeletePosts
 	}, nil
 }
 
-func (s *PostServiceServer) buildChangesJSON(updatedFields []string, oldValues map[string]interface{}, newPost *models.Post) string {
-	changes := make([]string, 0, len(updatedFields))
+// postDeletedEventBuilder returns the buildEvent callback DeletePostsWithOutbox
+// invokes once per post it actually deletes, so every post.deleted outbox
+// row produced by a single call shares that call's CorrelationIDs.
+func postDeletedEventBuilder(ctx context.Context) func(postID uint) (models.OutboxEvent, error) {
+	correlationIDs := events.IDsFromContext(ctx)
+	return func(postID uint) (models.OutboxEvent, error) {
+		envelope, err := events.NewPostDeleted(postID, "", correlationIDs)
+		if err != nil {
+			return models.OutboxEvent{}, fmt.Errorf("failed to build post.deleted event: %w", err)
+		}
+		data, err := envelope.Marshal()
+		if err != nil {
+			return models.OutboxEvent{}, fmt.Errorf("failed to build post.deleted event: %w", err)
+		}
+		metadata, err := envelope.MarshalMetadata()
+		if err != nil {
+			return models.OutboxEvent{}, fmt.Errorf("failed to build post.deleted event: %w", err)
+		}
+		return models.OutboxEvent{
+			AggregateType: "Post",
+			EventType:     envelope.EventType,
+			EventData:     data,
+			Metadata:      metadata,
+		}, nil
+	}
+}
+
+// DeletePostsByUserID deletes every post authored by userID and appends a
+// post.deleted outbox row per post actually deleted, without resolving
+// userID through userClient.GetUser first. It exists for HandleUserDeleted:
+// that cascade fires because the user-service just deleted userID, so a
+// GetUser round-trip for it would almost always fail with "not found".
+// Returns repository.ErrNoPostsMatched if userID had no posts - the caller
+// should treat that as a no-op success, not a failure.
+func (s *PostServiceServer) DeletePostsByUserID(ctx context.Context, userID string) ([]uint32, error) {
+	return s.repo.DeletePostsWithOutbox(nil, []string{userID}, postDeletedEventBuilder(ctx))
+}
+
+func (s *PostServiceServer) buildFieldChanges(updatedFields []string, oldValues map[string]interface{}, newPost *models.Post) map[string]events.FieldChange {
+	changes := make(map[string]events.FieldChange, len(updatedFields))
 
 	for _, field := range updatedFields {
 		var newValue interface{}
@@ -708,11 +865,40 @@ func (s *PostServiceServer) buildChangesJSON(updatedFields []string, oldValues m
 			newValue = newPost.IsFeatured
 		}
 
-		change := fmt.Sprintf(`"%s":{"old":"%v","new":"%v"}`, field, oldValues[field], newValue)
-		changes = append(changes, change)
+		changes[field] = events.FieldChange{Old: oldValues[field], New: newValue}
 	}
 
-	return fmt.Sprintf("{%s}", strings.Join(changes, ","))
+	return changes
+}
+
+// buildOutboxEvent stamps the envelope built by build with correlation IDs
+// propagated from the incoming request (falling back to freshly generated
+// ones), then marshals it into an OutboxEvent ready to commit alongside the
+// aggregate mutation it describes.
+func (s *PostServiceServer) buildOutboxEvent(ctx context.Context, build func(events.CorrelationIDs) (*events.Envelope, error)) (*models.OutboxEvent, error) {
+	ids := events.IDsFromContext(ctx)
+
+	envelope, err := build(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := envelope.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, err := envelope.MarshalMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.OutboxEvent{
+		AggregateType: "Post",
+		EventType:     envelope.EventType,
+		EventData:     data,
+		Metadata:      metadata,
+	}, nil
 }
 
 // Helper function to convert model to proto
@@ -721,36 +907,38 @@ func (s *PostServiceServer) modelToProto(post *models.Post) *pb.Post {
 
 	if err != nil {
 		return &pb.Post{
-			Id:         uint32(post.ID),
-			UserId:     post.UserID,
-			Img:        post.Img,
-			Title:      post.Title,
-			Slug:       post.Slug,
-			Desc:       post.Desc,
-			Category:   post.Category,
-			Content:    post.Content,
-			IsFeatured: post.IsFeatured,
-			Visit:      uint32(post.Visit),
-			Author:     nil,
-			CreatedAt:  timestamppb.New(post.CreatedAt),
-			UpdatedAt:  timestamppb.New(post.UpdatedAt),
+			Id:          uint32(post.ID),
+			UserId:      post.UserID,
+			Img:         post.Img,
+			Title:       post.Title,
+			Slug:        post.Slug,
+			Desc:        post.Desc,
+			Category:    post.Category,
+			Content:     post.Content,
+			IsFeatured:  post.IsFeatured,
+			Visit:       uint32(post.Visit),
+			Author:      nil,
+			Attachments: s.attachmentsToProto(post.ID),
+			CreatedAt:   timestamppb.New(post.CreatedAt),
+			UpdatedAt:   timestamppb.New(post.UpdatedAt),
 		}
 	}
 
 	// Create the protobuf Post object
 	pbPost := &pb.Post{
-		Id:         uint32(post.ID),
-		UserId:     post.UserID,
-		Img:        post.Img,
-		Title:      post.Title,
-		Slug:       post.Slug,
-		Desc:       post.Desc,
-		Category:   post.Category,
-		Content:    post.Content,
-		IsFeatured: post.IsFeatured,
-		Visit:      uint32(post.Visit),
-		CreatedAt:  timestamppb.New(post.CreatedAt),
-		UpdatedAt:  timestamppb.New(post.UpdatedAt),
+		Id:          uint32(post.ID),
+		UserId:      post.UserID,
+		Img:         post.Img,
+		Title:       post.Title,
+		Slug:        post.Slug,
+		Desc:        post.Desc,
+		Category:    post.Category,
+		Content:     post.Content,
+		IsFeatured:  post.IsFeatured,
+		Visit:       uint32(post.Visit),
+		Attachments: s.attachmentsToProto(post.ID),
+		CreatedAt:   timestamppb.New(post.CreatedAt),
+		UpdatedAt:   timestamppb.New(post.UpdatedAt),
 	}
 
 	// Set the author from the user response
@@ -765,6 +953,33 @@ func (s *PostServiceServer) modelToProto(post *models.Post) *pb.Post {
 	return pbPost
 }
 
+// attachmentsToProto loads a post's attachments in display order. A lookup
+// failure degrades to an empty list rather than failing the whole response.
+func (s *PostServiceServer) attachmentsToProto(postID uint) []*pb.Attachment {
+	attachments, err := s.attachmentRepo.ListByPost(postID)
+	if err != nil {
+		fmt.Printf("⚠️ Failed to load attachments for post %d: %v\n", postID, err)
+		return nil
+	}
+
+	result := make([]*pb.Attachment, len(attachments))
+	for i, a := range attachments {
+		result[i] = &pb.Attachment{
+			Id:             uint32(a.ID),
+			PostId:         attachmentPostID(a),
+			Kind:           string(a.Kind),
+			MimeType:       a.MimeType,
+			Size:           a.Size,
+			Width:          uint32(a.Width),
+			Height:         uint32(a.Height),
+			Url:            s.storage.PublicURL(a.StorageKey),
+			ChecksumSha256: a.ChecksumSHA256,
+			CreatedAt:      timestamppb.New(a.CreatedAt),
+		}
+	}
+	return result
+}
+
 func safeStringDeref(ptr *string) string {
 	if ptr == nil {
 		return ""
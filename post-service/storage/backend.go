@@ -0,0 +1,28 @@
+// post-service/storage/backend.go
+package storage
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// Backend stores attachment blobs. Most clients upload directly to the
+// backend using a pre-signed URL obtained via PresignUpload, so large media
+// never passes through the post-service process; the service only ever
+// holds the resulting StorageKey. Put exists for the smaller set of callers
+// that stream bytes through the service itself (e.g. UploadAttachment),
+// where the service needs the bytes in hand anyway to probe dimensions.
+type Backend interface {
+	// PresignUpload returns a URL the caller can PUT the object's bytes to
+	// directly, valid for expires.
+	PresignUpload(ctx context.Context, key string, contentType string, expires time.Duration) (string, error)
+	// Put writes size bytes read from r to key, for callers that already
+	// hold the object's bytes in-process rather than uploading directly.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Delete removes the object at key. Implementations should treat a
+	// missing object as success so cleanup is idempotent.
+	Delete(ctx context.Context, key string) error
+	// PublicURL returns the URL clients use to read the object back.
+	PublicURL(key string) string
+}
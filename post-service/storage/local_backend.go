@@ -0,0 +1,84 @@
+// post-service/storage/local_backend.go
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// LocalBackend stores attachments on local disk, under baseDir. It's meant
+// for development/single-node deployments; PresignUpload signs a short-lived
+// token for the local upload endpoint (see server.localUploadHandler) instead
+// of handing out real object-storage credentials.
+type LocalBackend struct {
+	baseDir   string
+	publicURL string
+	signKey   []byte
+}
+
+func NewLocalBackend(baseDir, publicURL, signKey string) *LocalBackend {
+	return &LocalBackend{baseDir: baseDir, publicURL: publicURL, signKey: []byte(signKey)}
+}
+
+func (b *LocalBackend) PresignUpload(ctx context.Context, key string, contentType string, expires time.Duration) (string, error) {
+	expiresAt := time.Now().Add(expires).Unix()
+	token := b.sign(key, expiresAt)
+	return fmt.Sprintf("%s/uploads/%s?expires=%d&token=%s", b.publicURL, key, expiresAt, token), nil
+}
+
+func (b *LocalBackend) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	path := filepath.Join(b.baseDir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", key, err)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create local attachment %s: %w", key, err)
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, r); err != nil {
+		return fmt.Errorf("failed to write local attachment %s: %w", key, err)
+	}
+	return nil
+}
+
+func (b *LocalBackend) Delete(ctx context.Context, key string) error {
+	path := filepath.Join(b.baseDir, filepath.FromSlash(key))
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete local attachment %s: %w", key, err)
+	}
+	return nil
+}
+
+// PublicURL returns <publicURL>/media/<key>. Nothing in this service serves
+// the /media/ path: the HTTP gateway registers only the /uploads/ PUT
+// endpoint for this backend.
+func (b *LocalBackend) PublicURL(key string) string {
+	return fmt.Sprintf("%s/media/%s", b.publicURL, key)
+}
+
+// VerifyUploadToken checks a token produced by PresignUpload, used by the
+// local upload HTTP handler to authorize a direct PUT.
+func (b *LocalBackend) VerifyUploadToken(key string, expiresAt int64, token string) bool {
+	if time.Now().Unix() > expiresAt {
+		return false
+	}
+	return hmac.Equal([]byte(token), []byte(b.sign(key, expiresAt)))
+}
+
+func (b *LocalBackend) sign(key string, expiresAt int64) string {
+	mac := hmac.New(sha256.New, b.signKey)
+	mac.Write([]byte(key))
+	mac.Write([]byte(strconv.FormatInt(expiresAt, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,75 @@
+// post-service/storage/s3_backend.go
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// S3Backend stores attachments in an S3-compatible object store (AWS S3,
+// MinIO, R2, ...) and hands out pre-signed PUT URLs so uploads go straight
+// from the client to the bucket.
+type S3Backend struct {
+	client    *s3.Client
+	presign   *s3.PresignClient
+	bucket    string
+	publicURL string
+}
+
+func NewS3Backend(client *s3.Client, bucket, publicURL string) *S3Backend {
+	return &S3Backend{
+		client:    client,
+		presign:   s3.NewPresignClient(client),
+		bucket:    bucket,
+		publicURL: publicURL,
+	}
+}
+
+func (b *S3Backend) PresignUpload(ctx context.Context, key string, contentType string, expires time.Duration) (string, error) {
+	req, err := b.presign.PresignPutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(b.bucket),
+		Key:         aws.String(key),
+		ContentType: aws.String(contentType),
+	}, s3.WithPresignExpires(expires))
+	if err != nil {
+		return "", fmt.Errorf("failed to presign upload for %s: %w", key, err)
+	}
+	return req.URL, nil
+}
+
+func (b *S3Backend) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	_, err := b.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(b.bucket),
+		Key:           aws.String(key),
+		Body:          r,
+		ContentLength: aws.Int64(size),
+		ContentType:   aws.String(contentType),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put object %s: %w", key, err)
+	}
+	return nil
+}
+
+func (b *S3Backend) Delete(ctx context.Context, key string) error {
+	_, err := b.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete object %s: %w", key, err)
+	}
+	return nil
+}
+
+func (b *S3Backend) PublicURL(key string) string {
+	if b.publicURL != "" {
+		return fmt.Sprintf("%s/%s", b.publicURL, key)
+	}
+	return fmt.Sprintf("https://%s.s3.amazonaws.com/%s", b.bucket, key)
+}